@@ -2,8 +2,17 @@
 package functools
 
 import (
+    "bufio"
+    "context"
     "fmt"
+    "math"
+    "math/rand"
     "reflect"
+    "runtime"
+    "sort"
+    "strings"
+    "sync"
+    "time"
 )
 
 func init() {
@@ -47,20 +56,16 @@ type MultiFunction func(...Anything) (Anything, Anything)
        z := Increment(10)
 */
 func Apply(f Anything, args ...Anything) Function {
-    // In order to work with any function type, we have to box it
-    // in Anything, and extract the true value using reflection.
-    fn := reflect.ValueOf(f)
+    // The fixed arguments are reflected once, up front, and each call
+    // only has to add the fresh ones (see partial for the details)
+    call := partial(f, args, "Apply")
 
     // We return a function which takes any number of additional arguments (0..N),
     // which when called will call the original function with all of the arguments
-    // aggregated.
+    // aggregated, and return the value boxed as Anything.
     var applied Function
     applied = func(moreargs ...Anything) Anything {
-        // Aggregate the two sets of arguments and extract
-        // the original argument values (arguments is []Anything)
-        values := AnythingToValues(append(args, moreargs...))
-        // Call the function using reflection, and return the value boxed as Anything
-        result := fn.Call(values)
+        result := call(moreargs)
         val := result[0].Interface()
         return val
     }
@@ -68,6 +73,188 @@ func Apply(f Anything, args ...Anything) Function {
     return applied
 }
 
+/*
+   partial does the heavy lifting for Apply and ApplyMulti. It returns a
+   function which calls f with args followed by whatever arguments it is
+   given, and returns the raw results of the call.
+
+   In order to work with any function type, we have to box it in Anything,
+   and extract the true value using reflection. The fixed arguments are
+   reflected once, here, rather than on every call, and the slice of
+   arguments passed to reflect is drawn from a pool of buffers sized to the
+   function's arity, so a partially applied function called in a hot loop
+   doesn't allocate a fresh argument slice each time. Since each call takes
+   its own buffer from the pool, the result is safe to call concurrently.
+
+   Before each call the arguments are checked against f's signature, so a
+   mismatch panics with a message naming the caller and the expected types,
+   rather than with whatever reflect makes of it.
+*/
+func partial(f Anything, args []Anything, caller string) func([]Anything) []reflect.Value {
+    fn := reflect.ValueOf(f)
+    if fn.Kind() != reflect.Func {
+        panic(fmt.Sprintf("Attempted to call %s on a value of type %T, which is not a function.", caller, f))
+    }
+    ftype := fn.Type()
+    fixed := make([]reflect.Value, len(args))
+    for i, arg := range args {
+        fixed[i] = argValue(ftype, i, arg)
+    }
+    arity := len(fixed)
+    if ftype.NumIn() > arity {
+        arity = ftype.NumIn()
+    }
+    buffers := &sync.Pool{
+        New: func() interface{} {
+            buffer := make([]reflect.Value, 0, arity)
+            return &buffer
+        },
+    }
+
+    return func(moreargs []Anything) []reflect.Value {
+        buffer := buffers.Get().(*[]reflect.Value)
+        values := append((*buffer)[:0], fixed...)
+        for _, arg := range moreargs {
+            values = append(values, argValue(ftype, len(values), arg))
+        }
+        if err := checkArgs(caller, ftype, values); err != nil {
+            panic(err.Error())
+        }
+        result := fn.Call(values)
+        // Don't keep the arguments alive while the buffer sits in the pool
+        for i := range values {
+            values[i] = reflect.Value{}
+        }
+        *buffer = values[:0]
+        buffers.Put(buffer)
+        return result
+    }
+}
+
+/*
+   argValue reflects arg, to be passed as argument i to a function of type
+   ftype. A nil arg has no type of its own, so it becomes the zero value of
+   the parameter, provided the parameter is of a type which can be nil.
+*/
+func argValue(ftype reflect.Type, i int, arg Anything) reflect.Value {
+    val := reflect.ValueOf(arg)
+    if !val.IsValid() {
+        if param := paramType(ftype, i); param != nil && isNilable(param.Kind()) {
+            return reflect.Zero(param)
+        }
+    }
+    return val
+}
+
+// paramType returns the type of argument i to ftype, or nil if there isn't one
+func paramType(ftype reflect.Type, i int) reflect.Type {
+    last := ftype.NumIn() - 1
+    switch {
+    case ftype.IsVariadic() && i >= last:
+        return ftype.In(last).Elem()
+    case i < ftype.NumIn():
+        return ftype.In(i)
+    }
+    return nil
+}
+
+// isNilable reports whether values of the given kind can be nil
+func isNilable(kind reflect.Kind) bool {
+    switch kind {
+    case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func:
+        return true
+    }
+    return false
+}
+
+/*
+   checkArgs returns an error, attributed to caller, if values can't be
+   passed to a function of type ftype, either because there are the wrong
+   number of them, or because one isn't assignable to its parameter. The
+   message is only built once a mismatch has been found, so checking a
+   valid call costs no more than a pass over the arguments.
+*/
+func checkArgs(caller string, ftype reflect.Type, values []reflect.Value) error {
+    required := ftype.NumIn()
+    if ftype.IsVariadic() {
+        required--
+    }
+    ok := len(values) == required || (ftype.IsVariadic() && len(values) > required)
+    for i := 0; ok && i < len(values); i++ {
+        ok = values[i].IsValid() && values[i].Type().AssignableTo(paramType(ftype, i))
+    }
+    if ok {
+        return nil
+    }
+
+    expected := make([]string, ftype.NumIn())
+    for i := range expected {
+        expected[i] = ftype.In(i).String()
+    }
+    quantity := ""
+    if ftype.IsVariadic() {
+        quantity = "at least "
+        expected[required] = "..." + ftype.In(required).Elem().String()
+    }
+    if len(values) < required || (len(values) > required && !ftype.IsVariadic()) {
+        return fmt.Errorf("%s: expected %s%d arguments of types (%s), got %d",
+            caller, quantity, required, strings.Join(expected, ", "), len(values))
+    }
+    got := make([]string, len(values))
+    for i, val := range values {
+        got[i] = "nil"
+        if val.IsValid() {
+            got[i] = val.Type().String()
+        }
+    }
+    return fmt.Errorf("%s: expected %s%d arguments of types (%s), got (%s)",
+        caller, quantity, required, strings.Join(expected, ", "), strings.Join(got, ", "))
+}
+
+/*
+   SafeCall calls f with args, returning its first result, and turns
+   anything which would otherwise panic into an error. The arguments are
+   checked against f's signature before the call, just as with Apply, and
+   a mismatch is returned as an error without calling f. A panic raised
+   by f itself is recovered and returned as an error too.
+
+   Example:
+       result, err := SafeCall(strings.Repeat, "ab", 2)  // => "abab", nil
+       result, err = SafeCall(strings.Repeat, "ab")      // => nil, SafeCall: expected 2 arguments of types (string, int), got 1
+       result, err = SafeCall(strings.Repeat, "ab", -1)  // => nil, SafeCall: strings: negative Repeat count
+*/
+func SafeCall(f Anything, args ...Anything) (result Anything, err error) {
+    fn := reflect.ValueOf(f)
+    if fn.Kind() != reflect.Func {
+        return nil, fmt.Errorf("SafeCall: value of type %T is not a function", f)
+    }
+    ftype := fn.Type()
+    values := make([]reflect.Value, len(args))
+    for i, arg := range args {
+        values[i] = argValue(ftype, i, arg)
+    }
+    if err := checkArgs("SafeCall", ftype, values); err != nil {
+        return nil, err
+    }
+    if ftype.NumOut() == 0 {
+        return nil, fmt.Errorf("SafeCall: function of type %s has no result", ftype)
+    }
+
+    // Only the call itself is guarded, so bugs above still panic as usual
+    results, err := func() (results []reflect.Value, err error) {
+        defer func() {
+            if r := recover(); r != nil {
+                err = fmt.Errorf("SafeCall: %v", r)
+            }
+        }()
+        return fn.Call(values), nil
+    }()
+    if err != nil {
+        return nil, err
+    }
+    return results[0].Interface(), nil
+}
+
 /*
    ApplyMulti performs the same function as Apply, but does it for
    functions with multiple return values. The behavior is more or
@@ -75,16 +262,15 @@ func Apply(f Anything, args ...Anything) Function {
    be self-explanatory.
 */
 func ApplyMulti(f Anything, args ...Anything) MultiFunction {
-    fn := reflect.ValueOf(f)
+    call := partial(f, args, "ApplyMulti")
 
     var applied MultiFunction
     applied = func(moreargs ...Anything) (Anything, Anything) {
-        values := AnythingToValues(append(args, moreargs...))
         // The convention with most multiple return functions is to store
         // the value of the operation in the first value, and the error, if
         // any, in the second. I've named the variables accordingly here, but
         // be aware that the values could really be any combination of two types.
-        result := fn.Call(values)
+        result := call(moreargs)
         val := result[0].Interface()
         err := result[1].Interface()
         return val, err
@@ -93,6 +279,204 @@ func ApplyMulti(f Anything, args ...Anything) MultiFunction {
     return applied
 }
 
+/*
+   MapValue returns a new MultiFunction which calls mf, and then, as long
+   as the error (second) component of its result is nil, replaces the value
+   (first) component with the result of calling f on it. When the error is
+   set, f is skipped and the result of mf is passed through untouched. This
+   lets ordinary single-return transforms be slotted into a pipeline of
+   fallible functions without unpacking each result by hand.
+
+   Example:
+       var ParseDouble = ApplyMulti(strconv.Atoi).MapValue(func(x int) int { return x * 2 })
+
+       ParseDouble("21")   // => 42, nil
+       ParseDouble("nope") // => 0, strconv.Atoi: parsing "nope": invalid syntax
+*/
+func (mf MultiFunction) MapValue(f Anything) MultiFunction {
+    expr := reflect.ValueOf(f)
+
+    var mapped MultiFunction
+    mapped = func(args ...Anything) (Anything, Anything) {
+        val, err := mf(args...)
+        if err != nil {
+            return val, err
+        }
+        return expr.Call([]reflect.Value{reflect.ValueOf(val)})[0].Interface(), nil
+    }
+
+    return mapped
+}
+
+/*
+   AndThenMap chains two fallible steps with a plain transform between
+   them. The resulting MultiFunction calls mf, applies valueFn to its value,
+   and passes the transformed value to nextMf, which may be a MultiFunction
+   or any function returning a (value, error) pair. It short-circuits at
+   each stage: if mf returns an error, neither valueFn nor nextMf is called,
+   and the result of mf is returned as-is; otherwise the result of nextMf,
+   error and all, is returned.
+
+   Example:
+       var LoadUser = AndThenMap(ApplyMulti(strconv.Atoi), func(id int) int64 { return int64(id) }, FindUser)
+
+       user, err := LoadUser("42")
+*/
+func AndThenMap(mf MultiFunction, valueFn Anything, nextMf Anything) MultiFunction {
+    transformed := mf.MapValue(valueFn)
+    next := reflect.ValueOf(nextMf)
+
+    var chained MultiFunction
+    chained = func(args ...Anything) (Anything, Anything) {
+        val, err := transformed(args...)
+        if err != nil {
+            return val, err
+        }
+        result := next.Call([]reflect.Value{reflect.ValueOf(val)})
+        return result[0].Interface(), result[1].Interface()
+    }
+
+    return chained
+}
+
+// placeholder is the type of Placeholder, unexported so it can't be forged
+type placeholder struct{}
+
+/*
+   Placeholder marks an argument to ApplyWith which is left open, to be
+   filled in when the resulting function is called. It is only ever equal
+   to itself, so it can't be confused with a real argument.
+*/
+var Placeholder Anything = placeholder{}
+
+/*
+   ApplyWith is a generalization of Apply which can fix any of a function's
+   arguments, not just the leading ones. Each Placeholder in args is left
+   open, and when the resulting function is called, its arguments fill the
+   open slots from left to right. Any arguments left over once every
+   placeholder is filled are appended at the end, just as with Apply.
+   Calling the result with fewer arguments than there are placeholders
+   causes a panic.
+
+   Example:
+       func Divide(a, b float64) float64 {
+           return a / b
+       }
+
+       var Half = ApplyWith(Divide, Placeholder, 2.0)
+       var Reciprocal = ApplyWith(Divide, 1.0, Placeholder)
+
+       Half(10.0)       // => 5
+       Reciprocal(4.0)  // => 0.25
+*/
+func ApplyWith(f Anything, args ...Anything) Function {
+    fn := reflect.ValueOf(f)
+
+    var applied Function
+    applied = func(moreargs ...Anything) Anything {
+        values := make([]Anything, 0, len(args)+len(moreargs))
+        for _, arg := range args {
+            if arg == Placeholder {
+                if len(moreargs) == 0 {
+                    panic("ApplyWith: not enough arguments to fill every Placeholder.")
+                }
+                arg, moreargs = moreargs[0], moreargs[1:]
+            }
+            values = append(values, arg)
+        }
+        values = append(values, moreargs...)
+        return fn.Call(AnythingToValues(values))[0].Interface()
+    }
+
+    return applied
+}
+
+/*
+   Curry turns a function of n arguments into a chain of n functions of
+   one argument each, so the arguments can be supplied one at a time. The
+   underlying function is only called once the last argument has been
+   supplied; until then, each call returns another Function. A function
+   with no arguments is called as soon as the curried function is.
+
+   Since a Function returns Anything, each intermediate result has to be
+   asserted back to a Function before it can be called. Variadic functions
+   have no fixed arity to curry, and cause a panic.
+
+   Example:
+       func Add3(a, b, c int) int {
+           return a + b + c
+       }
+
+       add := Curry(Add3)
+       add(1).(Function)(2).(Function)(3) // => 6
+*/
+func Curry(f Anything) Function {
+    fn := reflect.ValueOf(f)
+    if fn.Kind() != reflect.Func {
+        panic("Attempted to call Curry on a value which is not a function.")
+    }
+    if fn.Type().IsVariadic() {
+        panic("Attempted to call Curry on a variadic function, which has no fixed arity.")
+    }
+    return curried(fn, nil)
+}
+
+// curried returns the stage of a curried function which has been given args
+func curried(fn reflect.Value, args []reflect.Value) Function {
+    arity := fn.Type().NumIn()
+
+    var next Function
+    next = func(moreargs ...Anything) Anything {
+        if arity == 0 {
+            return fn.Call(nil)[0].Interface()
+        }
+        if len(moreargs) != 1 {
+            panic(fmt.Sprintf("Curry: expected a single argument, got %d.", len(moreargs)))
+        }
+        arg := reflect.ValueOf(moreargs[0])
+        if !arg.IsValid() {
+            arg = reflect.Zero(fn.Type().In(len(args)))
+        }
+        // Copy, so that sibling stages built from the same prefix don't share a slice
+        values := append(append(make([]reflect.Value, 0, arity), args...), arg)
+        if len(values) == arity {
+            return fn.Call(values)[0].Interface()
+        }
+        return curried(fn, values)
+    }
+
+    return next
+}
+
+/*
+   Identity returns its argument unchanged. Composing it with any function,
+   on either side, gives back the same function, which makes it a useful
+   default where a transform is optional.
+
+   Example:
+       list.Map(Identity) // => the same elements as list
+*/
+func Identity(x Anything) Anything {
+    return x
+}
+
+/*
+   Constant returns a function which ignores its arguments and always
+   returns x.
+
+   Example:
+       zero := Constant(0)
+       List(1, 2, 3).Map(zero) // => [0, 0, 0]
+*/
+func Constant(x Anything) Function {
+    var constant Function
+    constant = func(args ...Anything) Anything {
+        return x
+    }
+
+    return constant
+}
+
 /*
    Compose takes two functions, f1 and f2, and returns a new function
    that when called, applies it's arguments to f2, then applies the
@@ -126,108 +510,514 @@ func Compose(f1 Anything, f2 Anything) Function {
 }
 
 /*
-   AnythingToValues is used to return a slice of reflected values
-   for a slice of type Anything (which is really just interface{})
-*/
-func AnythingToValues(items []Anything) []reflect.Value {
-    values := make([]reflect.Value, len(items))
-    for k, v := range items {
-        values[k] = reflect.ValueOf(v)
-    }
-    return values
-}
+   ComposeMulti is like Compose, but for an inner function f2 which returns
+   a (value, error) pair. If the second value returned by f2 is a non-nil
+   error, f1 isn't called, and the error is returned as the result of the
+   composed function; otherwise the first value is passed on to f1. A
+   second value which doesn't implement error is never treated as one, and
+   an f2 with a single return value behaves just as with Compose.
 
-// LinkedList is simply a pointer to a function which will return the first Node
-type LinkedList func() *Node
+   Example:
+       func Double(x int) int {
+           return x * 2
+       }
 
-// Empty denotes the end of the list. It's value is nil.
-var Empty *LinkedList
+       var ParseDouble = ComposeMulti(Double, strconv.Atoi)
 
-/*
-   Every List is composed of Nodes. Each node contains a Head, or the
-   current value of this node; and a Tail, which is just another List
+       ParseDouble("21")  // => 42
+       ParseDouble("abc") // => strconv.Atoi: parsing "abc": invalid syntax
 */
-type Node struct {
-    Head Anything
-    Tail *LinkedList
+func ComposeMulti(f1 Anything, f2 Anything) Function {
+    fn1 := reflect.ValueOf(f1)
+    fn2 := reflect.ValueOf(f2)
+
+    var composed Function
+    composed = func(args ...Anything) Anything {
+        results := fn2.Call(AnythingToValues(args))
+        if len(results) > 1 {
+            if err, ok := results[1].Interface().(error); ok && err != nil {
+                return err
+            }
+        }
+        inside := results[0].Interface()
+        return fn1.Call([]reflect.Value{reflect.ValueOf(inside)})[0].Interface()
+    }
+
+    return composed
 }
 
-/* 
-   Creates a LinkedList from a head element and a tail Thunk, this is used
-   just like the `cons` operator in Lisp. You can chain Cons to build
-   a list, though it is quite verbose:
+/*
+   Pipe chains any number of functions from left to right, so that
+   Pipe(f, g, h)(x) is h(g(f(x))), applying the functions in the order
+   they are written. The first function may take any number of arguments,
+   and each function after it takes the single result of the one before.
+   This reads like a shell pipeline, and is often clearer than Compose for
+   data-flow code.
 
    Example:
-       list := Cons("A", Cons("B", Cons("C", Empty)))
+       func Add(a, b int) int {
+           return a + b
+       }
+       func Square(x int) int {
+           return x * x
+       }
+
+       var SumSquared = Pipe(Add, Square)
+
+       SumSquared(3, 3) // => 36
 */
-func Cons(head Anything, tail *LinkedList) *LinkedList {
-    var list LinkedList
-    list = func() *Node {
-        return &Node{head, tail}
+func Pipe(fns ...Anything) Function {
+    if len(fns) == 0 {
+        panic("Attempted to call Pipe without any functions.")
     }
-    return &list
+    stages := AnythingToValues(fns)
+
+    var piped Function
+    piped = func(args ...Anything) Anything {
+        result := stages[0].Call(AnythingToValues(args))[0].Interface()
+        for _, stage := range stages[1:] {
+            result = stage.Call([]reflect.Value{reflect.ValueOf(result)})[0].Interface()
+        }
+        return result
+    }
+
+    return piped
 }
 
 /*
-   Create a List from the provided arguments (or a slice using the ... syntax)
+   ComposeCtx composes any number of single-argument functions into a
+   cancellable pipeline. As with Compose, functions are applied from right
+   to left, so ComposeCtx(f, g, h)(ctx, x) computes f(g(h(x))).
+
+   Before each stage is invoked the context is checked, and if it has been
+   cancelled the pipeline stops and returns the context's error. Stages are
+   ordinary functions, so a stage which is already running is not
+   interrupted; cancellation takes effect between stages.
 
    Example:
-       nums  := [...]int{1, 2, 3}
-       list  := List(1, 2, 3) // => [1, 2, 3]
-       list2 := List(nums...) // => [1, 2, 3]
+       pipeline := ComposeCtx(Render, Transform, Parse)
+       result, err := pipeline(ctx, input)
 */
-func List(elements ...Anything) *LinkedList {
-    result := Empty
-    if len(elements) > 0 {
-        result = Cons(elements[0], List(elements[1:]...))
+func ComposeCtx(fns ...Anything) func(context.Context, Anything) (Anything, error) {
+    stages := AnythingToValues(fns)
+
+    return func(ctx context.Context, arg Anything) (Anything, error) {
+        result := arg
+        for i := len(stages) - 1; i >= 0; i-- {
+            if err := ctx.Err(); err != nil {
+                return nil, err
+            }
+            result = stages[i].Call([]reflect.Value{reflect.ValueOf(result)})[0].Interface()
+        }
+        return result, nil
     }
-    return result
 }
 
 /*
-   Create a list using a generator function.
+   PipeWithInput chains functions from left to right, while also threading
+   the original arguments through every stage. The first function is called
+   with the original arguments, and each function after it is called with
+   the previous result followed by the original arguments again:
+
+       PipeWithInput(f, g, h)(x, y) == h(g(f(x, y), x, y), x, y)
+
+   So every stage after the first must accept one more argument than the
+   pipeline is called with. Stages are checked when the pipeline is built
+   and again when it is called, panicking with a clear message if a stage
+   can't accept the arguments it will be given.
 
    Example:
-       doubles := Generate(2, func(x int) int { return x * 2 }) // => [2, 4...]
+       func Discount(price float64) float64 { return price * 0.9 }
+       func Label(discounted float64, price float64) string {
+           return fmt.Sprintf("%.2f (was %.2f)", discounted, price)
+       }
+
+       var Describe = PipeWithInput(Discount, Label)
+
+       Describe(10.0) // => "9.00 (was 10.00)"
 */
-func Generate(head, f Anything) *LinkedList {
-    generator := reflect.ValueOf(f)
-    var list LinkedList
-    list = func() *Node {
-        args := []reflect.Value{reflect.ValueOf(head)}
-        next := generator.Call(args)[0].Interface()
-        return &Node{head, Generate(next, f)}
+func PipeWithInput(fns ...Anything) Function {
+    stages := AnythingToValues(fns)
+    if len(stages) == 0 {
+        panic("Attempted to call PipeWithInput without any functions.")
     }
-    return &list
+    for i, stage := range stages {
+        if stage.Kind() != reflect.Func {
+            panic(fmt.Sprintf("Attempted to call PipeWithInput with a %s as stage %d. Must be a function.", stage.Kind(), i))
+        }
+        if i > 0 && stage.Type().NumIn() == 0 {
+            panic(fmt.Sprintf("Attempted to call PipeWithInput with a stage %d which takes no arguments. Must accept the previous result.", i))
+        }
+    }
+
+    var piped Function
+    piped = func(args ...Anything) Anything {
+        values := AnythingToValues(args)
+        result := stages[0].Call(values)[0].Interface()
+        for i, stage := range stages[1:] {
+            stageType := stage.Type()
+            if !stageType.IsVariadic() && stageType.NumIn() != len(args)+1 {
+                panic(fmt.Sprintf("PipeWithInput: stage %d takes %d arguments, but is given the previous result and %d original arguments.", i+1, stageType.NumIn(), len(args)))
+            }
+            result = stage.Call(append([]reflect.Value{reflect.ValueOf(result)}, values...))[0].Interface()
+        }
+        return result
+    }
+
+    return piped
 }
 
 /*
-   Gets the length of the List. Calling this on an infinite list
-   will cause an endless loop. Care is required!
+   Fallback combines several functions, each returning a (value, error)
+   pair, into a MultiFunction which tries them in order with the same
+   arguments. It stops at, and returns the result of, the first function
+   whose error is nil. If every function fails, the result of the last one,
+   including its error, is returned.
+
+   Example:
+       var Load = Fallback(LoadFromCache, LoadFromDisk, LoadFromNetwork)
+
+       config, err := Load("settings.json")
 */
-func (list *LinkedList) Length() int {
-    length := 0
-    node := (*list)()
-    for node != nil {
-        node = (*node.Tail)()
-        length++
+func Fallback(fns ...Anything) MultiFunction {
+    if len(fns) == 0 {
+        panic("Attempted to call Fallback without any functions.")
     }
-    return length
+    candidates := AnythingToValues(fns)
+
+    var fallback MultiFunction
+    fallback = func(args ...Anything) (Anything, Anything) {
+        values := AnythingToValues(args)
+        var val, err Anything
+        for _, candidate := range candidates {
+            result := candidate.Call(values)
+            val, err = result[0].Interface(), result[1].Interface()
+            if err == nil {
+                break
+            }
+        }
+        return val, err
+    }
+
+    return fallback
 }
 
 /*
-   Converts a slice of any type to a LinkedList
+   ValidateAll combines several validators, each of the form
+   func(x T) error, into a single function which runs every one of them
+   against its input and collects all of the errors, in order. Unlike a
+   chain which stops at the first failure, this reports every problem at
+   once. When every validator passes, the result is nil.
 
    Example:
-       nums := [...]int{1, 2, 3}
+       var CheckUser = ValidateAll(RequireName, RequireEmail, CheckAge)
+
+       for _, err := range CheckUser(user) {
+           fmt.Println(err)
+       }
+*/
+func ValidateAll(validators ...Anything) func(Anything) []error {
+    checks := AnythingToValues(validators)
+    return func(x Anything) []error {
+        var errs []error
+        args := []reflect.Value{reflect.ValueOf(x)}
+        for _, check := range checks {
+            if err, ok := check.Call(args)[0].Interface().(error); ok && err != nil {
+                errs = append(errs, err)
+            }
+        }
+        return errs
+    }
+}
+
+/*
+   ComposeComparators combines several three-way comparators into one
+   which compares lexicographically. Each comparator is of the form
+   cmp(a, b) int, returning a negative number when a sorts before b, zero
+   when they are tied, and a positive number when a sorts after b. The
+   composed comparator tries each in turn, falling through to the next
+   only when the current one returns zero, so later comparators break the
+   ties of earlier ones. If every comparator ties, the result is zero.
+
+   Example:
+       byLastName := func(a, b Person) int { return strings.Compare(a.Last, b.Last) }
+       byFirstName := func(a, b Person) int { return strings.Compare(a.First, b.First) }
+       byName := ComposeComparators(byLastName, byFirstName)
+
+       // Adapt the three-way comparator into a less function for sorting
+       less := func(a, b Person) bool { return byName(a, b).(int) < 0 }
+*/
+func ComposeComparators(cmps ...Anything) Function {
+    comparators := AnythingToValues(cmps)
+
+    var composed Function
+    composed = func(args ...Anything) Anything {
+        values := AnythingToValues(args)
+        for _, cmp := range comparators {
+            if result := int(cmp.Call(values)[0].Int()); result != 0 {
+                return result
+            }
+        }
+        return 0
+    }
+
+    return composed
+}
+
+/*
+   AnythingToValues is used to return a slice of reflected values
+   for a slice of type Anything (which is really just interface{})
+*/
+func AnythingToValues(items []Anything) []reflect.Value {
+    values := make([]reflect.Value, len(items))
+    for k, v := range items {
+        values[k] = reflect.ValueOf(v)
+    }
+    return values
+}
+
+// LinkedList is simply a pointer to a function which will return the first Node
+type LinkedList func() *Node
+
+// Empty denotes the end of the list. It's value is nil.
+var Empty *LinkedList
+
+/*
+   Every List is composed of Nodes. Each node contains a Head, or the
+   current value of this node; and a Tail, which is just another List
+*/
+type Node struct {
+    Head Anything
+    Tail *LinkedList
+}
+
+/* 
+   Creates a LinkedList from a head element and a tail Thunk, this is used
+   just like the `cons` operator in Lisp. You can chain Cons to build
+   a list, though it is quite verbose:
+
+   Example:
+       list := Cons("A", Cons("B", Cons("C", Empty)))
+*/
+func Cons(head Anything, tail *LinkedList) *LinkedList {
+    var list LinkedList
+    list = func() *Node {
+        return &Node{head, tail}
+    }
+    return &list
+}
+
+/*
+   Create a List from the provided arguments (or a slice using the ... syntax)
+
+   Example:
+       nums  := [...]int{1, 2, 3}
+       list  := List(1, 2, 3) // => [1, 2, 3]
+       list2 := List(nums...) // => [1, 2, 3]
+*/
+func List(elements ...Anything) *LinkedList {
+    result := Empty
+    if len(elements) > 0 {
+        result = Cons(elements[0], List(elements[1:]...))
+    }
+    return result
+}
+
+/*
+   Create a list using a generator function.
+
+   Example:
+       doubles := Generate(2, func(x int) int { return x * 2 }) // => [2, 4...]
+*/
+func Generate(head, f Anything) *LinkedList {
+    generator := reflect.ValueOf(f)
+    var list LinkedList
+    list = func() *Node {
+        args := []reflect.Value{reflect.ValueOf(head)}
+        next := generator.Call(args)[0].Interface()
+        return &Node{head, Generate(next, f)}
+    }
+    return &list
+}
+
+/*
+   Create a lazy list of integers, counting from start up to (but not
+   including) stop, in increments of step. A negative step counts down
+   instead, stopping just before reaching stop. A step of zero would never
+   get anywhere, so it causes a panic. Since elements are only produced as
+   they are forced, even huge ranges are cheap until they are walked.
+
+   Example:
+       Range(0, 10, 3)                  // => [0, 3, 6, 9]
+       Range(5, 0, -2)                  // => [5, 3, 1]
+       Range(0, math.MaxInt, 1).Take(3) // => [0, 1, 2]
+*/
+func Range(start, stop, step int) *LinkedList {
+    if step == 0 {
+        panic("Attempted to call Range with a step of zero.")
+    }
+    var numbers LinkedList
+    numbers = func() *Node {
+        if (step > 0 && start >= stop) || (step < 0 && start <= stop) {
+            return nil
+        }
+        next := start + step
+        // Stop, rather than wrap around, if the next step would overflow
+        if (step > 0 && next < start) || (step < 0 && next > start) {
+            return &Node{start, Empty}
+        }
+        return &Node{start, Range(next, stop, step)}
+    }
+    return &numbers
+}
+
+/*
+   Create an infinite list in which every element is value. The list is a
+   single node whose tail is the list itself, so it takes constant memory
+   however far it is walked.
+
+   Example:
+       xs := Repeat("x").Take(3) // => [x, x, x]
+*/
+func Repeat(value Anything) *LinkedList {
+    var list LinkedList
+    node := &Node{value, &list}
+    list = func() *Node { return node }
+    return &list
+}
+
+/*
+   Create an infinite list by repeatedly applying f, yielding seed, f(seed),
+   f(f(seed)), and so on. This is the same as Generate, with the arguments
+   in the conventional order for iterate.
+
+   Example:
+       powers := Iterate(func(x int) int { return x * 2 }, 1) // => [1, 2, 4, 8...]
+*/
+func Iterate(f Anything, seed Anything) *LinkedList {
+    return Generate(seed, f)
+}
+
+/*
+   Create an infinite list which repeats the elements of a finite list over
+   and over. Cycling an empty list gives an empty list, rather than looping
+   forever in search of an element.
+
+   Example:
+       Cycle(List(1, 2, 3)).Take(7) // => [1, 2, 3, 1, 2, 3, 1]
+*/
+func Cycle(list *LinkedList) *LinkedList {
+    var cycled LinkedList
+    cycled = func() *Node {
+        node := (*list)()
+        if node != nil {
+            // Once the list runs out, start again from the top
+            return &Node{node.Head, node.Tail.Concat(&cycled)}
+        }
+        return nil
+    }
+    return &cycled
+}
+
+/*
+   Create a lazy list by unfolding a seed, the opposite of Reduce. The
+   function is called as f(state) and must return three values: the next
+   element, the state to produce the element after it from, and a bool
+   which is false when the list should end (in which case the other two
+   values are ignored). An unfold which never ends is fine, as long as it
+   is combined with Take.
+
+   Example:
+       doubling := Unfold(func(x int) (int, int, bool) {
+           return x, x * 2, x <= 100
+       }, 1) // => [1, 2, 4, 8, 16, 32, 64]
+*/
+func Unfold(f Anything, seed Anything) *LinkedList {
+    var unfolded LinkedList
+    unfolded = func() *Node {
+        result := reflect.ValueOf(f).Call([]reflect.Value{reflect.ValueOf(seed)})
+        if result[2].Bool() {
+            return &Node{result[0].Interface(), Unfold(f, result[1].Interface())}
+        }
+        return nil
+    }
+    return &unfolded
+}
+
+/*
+   Gets the length of the List. Calling this on an infinite list
+   will cause an endless loop. Care is required! Use LengthUpTo
+   when the list may be infinite.
+*/
+func (list *LinkedList) Length() int {
+    length := 0
+    node := (*list)()
+    for node != nil {
+        node = (*node.Tail)()
+        length++
+    }
+    return length
+}
+
+/*
+   LengthUpTo counts the elements of the list, but stops once it has
+   counted max of them, so it returns max for any list at least that
+   long. No more than max elements are forced, which makes it safe to
+   probe the size of a list which may be infinite.
+
+   Example:
+       Repeat(1).LengthUpTo(5)  // => 5
+       List(1, 2).LengthUpTo(5) // => 2
+*/
+func (list *LinkedList) LengthUpTo(max int) int {
+    length := 0
+    for length < max {
+        node := (*list)()
+        if node == nil {
+            break
+        }
+        list = node.Tail
+        length++
+    }
+    return length
+}
+
+/*
+   Force evaluates the first node of the list and returns it, or nil if the
+   list is empty. It's equivalent to calling (*list)(), and is the
+   preferred low-level way to step through a list by hand.
+
+   Example:
+       for node := list.Force(); node != nil; node = node.Tail.Force() {
+           fmt.Println(node.Head)
+       }
+*/
+func (list *LinkedList) Force() *Node {
+    return (*list)()
+}
+
+/*
+   IsEmpty reports whether the list has no elements. Only the first node is
+   forced, so unlike comparing Length to 0, this is O(1) and safe to call
+   on an infinite list.
+*/
+func (list *LinkedList) IsEmpty() bool {
+    return list.Force() == nil
+}
+
+/*
+   Converts a slice or array of any type to a LinkedList
+
+   Example:
+       nums := [...]int{1, 2, 3}
        list := ToList(nums)
 
 */
 func ToList(elements Anything) *LinkedList {
     sliceType := reflect.TypeOf(elements)
     result := Empty
-    if elements == nil || sliceType.Kind() != reflect.Slice {
-        panic("Attempted to call ToList on a value of the wrong type. Must be Slice.")
+    if elements == nil || (sliceType.Kind() != reflect.Slice && sliceType.Kind() != reflect.Array) {
+        panic("Attempted to call ToList on a value of the wrong type. Must be Slice or Array.")
     } else {
         val := reflect.ValueOf(elements)
         // Build the list in reverse
@@ -251,14 +1041,26 @@ func ToSlice(list *LinkedList) []Anything {
     return result
 }
 
+// StringLimit is the most elements String will render before truncating the list
+var StringLimit = 100
+
 /*
-   Render a list like a slice, e.g. [1, 2, 3]
+   Render a list like a slice, e.g. [1, 2, 3]. Only the first StringLimit
+   elements are rendered, followed by "..." if there are more, so that
+   printing an infinite list doesn't hang. A nil list renders as [].
 */
 func (list *LinkedList) String() string {
     result := "["
-    // Iterate over each node, until we hit Empty (nil)
+    if list == nil {
+        return result + "]"
+    }
+    // Iterate over each node, until we hit Empty (nil) or the limit
     node := (*list)()
-    for node != nil {
+    for rendered := 0; node != nil; rendered++ {
+        if rendered == StringLimit {
+            result += "..."
+            break
+        }
         result += fmt.Sprintf("%v", node.Head)
         node = (*node.Tail)()
         // Tag a comma between intermediate elements
@@ -289,18 +1091,64 @@ func (list *LinkedList) Take(n int) *LinkedList {
 
 /*
    Returns a new LinkedList with the first n elements dropped.
+   Each time the result is forced it skips from the start of the
+   original list, so forcing it again gives the same nodes.
 */
 func (list *LinkedList) Drop(n int) *LinkedList {
     var remaining LinkedList
     remaining = func() *Node {
+        node := (*list)()
+        for i := 0; i < n && node != nil; i++ {
+            node = (*node.Tail)()
+        }
+        return node
+    }
+    return &remaining
+}
+
+/*
+   Returns a new LinkedList of the leading elements which satisfy the
+   predicate, stopping at the first element that doesn't. Nothing after
+   that element is forced, so this is the standard way of bounding an
+   infinite list.
+
+   Example:
+       nums := Generate(1, func(x int) int { return x + 1 })
+       small := nums.TakeWhile(func(x int) bool { return x < 4 }) // => [1, 2, 3]
+*/
+func (list *LinkedList) TakeWhile(f Anything) *LinkedList {
+    expr := reflect.ValueOf(f)
+    var taken LinkedList
+    taken = func() *Node {
         node := (*list)()
         if node != nil {
-            if n > 0 {
-                n--
-                list = node.Tail
-                return remaining()
+            args := []reflect.Value{reflect.ValueOf(node.Head)}
+            if expr.Call(args)[0].Bool() {
+                return &Node{node.Head, node.Tail.TakeWhile(f)}
+            }
+        }
+        return nil
+    }
+    return &taken
+}
+
+/*
+   Returns a new LinkedList with the leading elements which satisfy the
+   predicate dropped, starting from the first element that doesn't.
+
+   Example:
+       list := List(1, 2, 3, 1)
+       rest := list.DropWhile(func(x int) bool { return x < 3 }) // => [3, 1]
+*/
+func (list *LinkedList) DropWhile(f Anything) *LinkedList {
+    expr := reflect.ValueOf(f)
+    var remaining LinkedList
+    remaining = func() *Node {
+        for node := (*list)(); node != nil; node = (*node.Tail)() {
+            args := []reflect.Value{reflect.ValueOf(node.Head)}
+            if !expr.Call(args)[0].Bool() {
+                return node
             }
-            return node
         }
         return nil
     }
@@ -310,19 +1158,27 @@ func (list *LinkedList) Drop(n int) *LinkedList {
 /*
    Maps a function to each element of a list. This is a lazy operation.
 
+   Forcing a node of the mapped list only forces the matching node of the
+   original list, leaving the mapped tail as another unevaluated thunk, so
+   walking the result takes constant stack depth however long it is.
+
    Example:
        list := List(1, 2, 3)
        squared := list.Map(func(x int) int { return x * x })
 */
 func (list *LinkedList) Map(f Anything) *LinkedList {
-    expr := reflect.ValueOf(f)
+    return mapFrom(list, reflect.ValueOf(f))
+}
+
+// mapFrom implements Map, so that f is only reflected once for the whole list
+func mapFrom(list *LinkedList, expr reflect.Value) *LinkedList {
     var mapped LinkedList
     mapped = func() *Node {
         node := (*list)()
         if node != nil {
             args := []reflect.Value{reflect.ValueOf(node.Head)}
             head := expr.Call(args)[0].Interface()
-            tail := node.Tail.Map(f)
+            tail := mapFrom(node.Tail, expr)
             return &Node{head, tail}
         }
         return nil
@@ -330,6 +1186,31 @@ func (list *LinkedList) Map(f Anything) *LinkedList {
     return &mapped
 }
 
+/*
+   Filters a list, keeping only the elements for which the predicate
+   returns true. This is a lazy operation, so it can be used on an
+   infinite list, as long as you Take from it.
+
+   Example:
+       list := List(1, 2, 3, 4)
+       evens := list.Filter(func(x int) bool { return x%2 == 0 }) // => [2, 4]
+*/
+func (list *LinkedList) Filter(f Anything) *LinkedList {
+    expr := reflect.ValueOf(f)
+    var filtered LinkedList
+    filtered = func() *Node {
+        // Skip over rejected elements in a loop, rather than recursing
+        for node := (*list)(); node != nil; node = (*node.Tail)() {
+            args := []reflect.Value{reflect.ValueOf(node.Head)}
+            if expr.Call(args)[0].Bool() {
+                return &Node{node.Head, node.Tail.Filter(f)}
+            }
+        }
+        return nil
+    }
+    return &filtered
+}
+
 /*
    Reduces the elements of a list to a single value.
 
@@ -347,3 +1228,2884 @@ func (list *LinkedList) Reduce(f Anything, memo Anything) Anything {
     }
     return memo
 }
+
+/*
+   Reduce2 is like Reduce, but carries two accumulators through the fold
+   rather than one. The reducer is called as f(acc1, acc2, x) and must
+   return the two updated accumulators. The fold is strict, and runs from
+   left to right.
+
+   Example:
+       list := List(1, 2, 3, 4)
+       sum, count := list.Reduce2(func(sum, count, x int) (int, int) {
+           return sum + x, count + 1
+       }, 0, 0)
+       mean := float64(sum.(int)) / float64(count.(int)) // => 2.5
+*/
+func (list *LinkedList) Reduce2(f Anything, memo1, memo2 Anything) (Anything, Anything) {
+    expr := reflect.ValueOf(f)
+    node := (*list)()
+    for node != nil {
+        args := []reflect.Value{reflect.ValueOf(memo1), reflect.ValueOf(memo2), reflect.ValueOf(node.Head)}
+        result := expr.Call(args)
+        memo1 = result[0].Interface()
+        memo2 = result[1].Interface()
+        node = (*node.Tail)()
+    }
+    return memo1, memo2
+}
+
+/*
+   FoldRight reduces the elements of a list to a single value, like
+   Reduce, but associates from the right: for a list [x1, x2, x3] it
+   computes f(x1, f(x2, f(x3, memo))). Note that the reducer takes the
+   element first and the accumulator second, which is the conventional
+   order for a right fold. This matters for operations which aren't
+   associative, such as building up a new list.
+
+   The fold is implemented recursively, so its stack depth grows with the
+   length of the list, and like Length, it will never return when called
+   on an infinite list.
+
+   Example:
+       list := List("a", "b", "c")
+       nested := list.FoldRight(func(x, acc string) string { return "(" + x + acc + ")" }, "")
+       // => "(a(b(c)))"
+*/
+func (list *LinkedList) FoldRight(f Anything, memo Anything) Anything {
+    return foldRight(list, reflect.ValueOf(f), memo)
+}
+
+// foldRight implements FoldRight with an already reflected f
+func foldRight(list *LinkedList, expr reflect.Value, memo Anything) Anything {
+    node := (*list)()
+    if node == nil {
+        return memo
+    }
+    acc := foldRight(node.Tail, expr, memo)
+    args := []reflect.Value{reflect.ValueOf(node.Head), reflect.ValueOf(acc)}
+    return expr.Call(args)[0].Interface()
+}
+
+/*
+   Freeze walks the list to completion and returns a fully-materialized
+   copy of it. Every node of the copy is built up front, so traversing
+   the result never calls back into whatever produced the original list.
+   This is useful for detaching a list from a stateful source, such as a
+   channel or generator, into an immutable, replayable list.
+
+   Like Length, this will loop forever on an infinite list, so bound
+   unbounded sources with Take first.
+
+   Example:
+       frozen := Generate(1, func(x int) int { return x * 2 }).Take(3).Freeze() // => [1, 2, 4]
+*/
+func (list *LinkedList) Freeze() *LinkedList {
+    // Walk the list exactly once, since a stateful source may not replay
+    var elements []Anything
+    for node := (*list)(); node != nil; node = (*node.Tail)() {
+        elements = append(elements, node.Head)
+    }
+    result := Empty
+    // Build the copy in reverse, so each node can point at its fixed tail
+    for i := len(elements) - 1; i >= 0; i-- {
+        frozen := &Node{elements[i], result}
+        var cell LinkedList
+        cell = func() *Node { return frozen }
+        result = &cell
+    }
+    return result
+}
+
+/*
+   LessFunc adapts a comparator of the form less(a, b) bool, where a and b
+   may be of any concrete type, into a func(a, b Anything) bool. This makes
+   the comparator-style functions used throughout this package easy to
+   hand to code which expects a plain Go function.
+
+   Example:
+       less := LessFunc(func(a, b int) bool { return a < b })
+       less(1, 2) // => true
+*/
+func LessFunc(less Anything) func(a, b Anything) bool {
+    fn := reflect.ValueOf(less)
+    // The Kind check must come first, since Type panics on a nil comparator
+    if fn.Kind() != reflect.Func || fn.Type().NumIn() != 2 || fn.Type().NumOut() != 1 || fn.Type().Out(0).Kind() != reflect.Bool {
+        panic("Attempted to call LessFunc with the wrong type of comparator. Must be func(a, b) bool.")
+    }
+    return func(a, b Anything) bool {
+        args := []reflect.Value{reflect.ValueOf(a), reflect.ValueOf(b)}
+        return fn.Call(args)[0].Bool()
+    }
+}
+
+/*
+   LessIndexFunc adapts a comparator into the func(i, j int) bool closure
+   expected by sort.Slice, comparing the elements at i and j of items.
+
+   Example:
+       items := ToSlice(List(3, 1, 2))
+       sort.Slice(items, LessIndexFunc(func(a, b int) bool { return a < b }, items))
+*/
+func LessIndexFunc(less Anything, items []Anything) func(i, j int) bool {
+    fn := LessFunc(less)
+    return func(i, j int) bool {
+        return fn(items[i], items[j])
+    }
+}
+
+/*
+   GroupConsecutiveBy lazily groups runs of consecutive elements which
+   share the same key, as determined by keyFn. Each element of the result
+   is a []Anything{key, group}, where group is a *LinkedList of the
+   elements in that run. Keys are compared with reflect.DeepEqual.
+
+   Each group is built when its pair is forced, so this works on infinite
+   lists when combined with Take, as long as every run is finite.
+
+   Example:
+       list := List(1, 3, 2, 4, 5)
+       groups := list.GroupConsecutiveBy(func(x int) bool { return x%2 == 0 })
+       // => [[false [1, 3]], [true [2, 4]], [false [5]]]
+*/
+func (list *LinkedList) GroupConsecutiveBy(keyFn Anything) *LinkedList {
+    expr := reflect.ValueOf(keyFn)
+    var grouped LinkedList
+    grouped = func() *Node {
+        node := (*list)()
+        if node == nil {
+            return nil
+        }
+        key := expr.Call([]reflect.Value{reflect.ValueOf(node.Head)})[0].Interface()
+        members := []Anything{node.Head}
+        // Consume the run, stopping at the first element with a different key
+        rest := node.Tail
+        for next := (*rest)(); next != nil; next = (*rest)() {
+            nextKey := expr.Call([]reflect.Value{reflect.ValueOf(next.Head)})[0].Interface()
+            if !reflect.DeepEqual(key, nextKey) {
+                break
+            }
+            members = append(members, next.Head)
+            rest = next.Tail
+        }
+        return &Node{[]Anything{key, ToList(members)}, rest.GroupConsecutiveBy(keyFn)}
+    }
+    return &grouped
+}
+
+/*
+   Intersperse lazily yields the elements of the list with sep placed
+   between each adjacent pair: element, sep, element, sep, element. No
+   separator is placed before the first element or after the last one, so
+   an empty or single element list comes back unchanged. Only as much of
+   the list is forced as is consumed, so it works on infinite lists.
+
+   Example:
+       list := List("a", "b", "c")
+       parts := list.Intersperse(",") // => [a, ,, b, ,, c]
+*/
+func (list *LinkedList) Intersperse(sep Anything) *LinkedList {
+    var interspersed LinkedList
+    interspersed = func() *Node {
+        node := (*list)()
+        if node != nil {
+            return &Node{node.Head, separated(node.Tail, sep)}
+        }
+        return nil
+    }
+    return &interspersed
+}
+
+/*
+   JoinLazy is Intersperse under the name it was first added with: the
+   elements of the list, with sep placed between each adjacent pair.
+
+   This is the lazy, non-string counterpart of joining values into a
+   delimited string, and is intended to be paired with a flattening step
+   when each element (and possibly sep) is itself a sub-sequence.
+
+   Example:
+       list := List("a", "b", "c")
+       joined := list.JoinLazy(",") // => [a, ,, b, ,, c]
+*/
+func (list *LinkedList) JoinLazy(sep Anything) *LinkedList {
+    return list.Intersperse(sep)
+}
+
+// separated yields sep followed by the next element, for each remaining element
+func separated(list *LinkedList, sep Anything) *LinkedList {
+    var result LinkedList
+    result = func() *Node {
+        node := (*list)()
+        if node != nil {
+            return &Node{sep, Cons(node.Head, separated(node.Tail, sep))}
+        }
+        return nil
+    }
+    return &result
+}
+
+/*
+   ParallelMap maps f over every element of the list concurrently, using a
+   pool of workers goroutines (or GOMAXPROCS, if workers <= 0), and returns
+   the results in their original order. Unlike Map, this is eager: the
+   whole list is forced up front, so it must only be used on finite lists.
+   A panic in f doesn't deadlock the pool; the remaining work is abandoned
+   and the panic is re-raised in the calling goroutine. Use PMapCtx to be
+   able to cancel the work part way through.
+
+   Example:
+       hashes := files.ParallelMap(hashFile, 8)
+*/
+func (list *LinkedList) ParallelMap(f Anything, workers int) *LinkedList {
+    results, _ := parallelMap(context.Background(), ToSlice(list), workers, reflect.ValueOf(f))
+    return ToList(results)
+}
+
+/*
+   PMapCtx maps f over every element of the list concurrently, using at
+   most workers goroutines (or GOMAXPROCS, if workers <= 0), and returns
+   the results in their original order. This forces the entire list up
+   front, so it should only be used on finite lists.
+
+   If ctx is cancelled before every element has been mapped, PMapCtx
+   returns promptly with the context's error, and any results computed so
+   far are discarded. Workers stop picking up new elements once this
+   happens, exiting as soon as their current call to f returns. A panic in
+   f is re-raised in the calling goroutine.
+
+   Example:
+       ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+       defer cancel()
+       squared, err := List(1, 2, 3).PMapCtx(ctx, 2, func(x int) int { return x * x })
+*/
+func (list *LinkedList) PMapCtx(ctx context.Context, workers int, f Anything) (*LinkedList, error) {
+    results, err := parallelMap(ctx, ToSlice(list), workers, reflect.ValueOf(f))
+    if err != nil {
+        return nil, err
+    }
+    return ToList(results), nil
+}
+
+// parallelMap applies expr to each of items using a pool of worker goroutines
+func parallelMap(ctx context.Context, items []Anything, workers int, expr reflect.Value) ([]Anything, error) {
+    if workers <= 0 {
+        workers = runtime.GOMAXPROCS(0)
+    }
+    results := make([]Anything, len(items))
+    jobs := make(chan int)
+    // Closed on return, so the feeder never blocks on workers that are gone
+    stop := make(chan struct{})
+    defer close(stop)
+    // Buffered, so a panicking worker never blocks once we've stopped listening
+    panics := make(chan interface{}, workers)
+
+    var wg sync.WaitGroup
+    wg.Add(workers)
+    for w := 0; w < workers; w++ {
+        go func() {
+            defer wg.Done()
+            defer func() {
+                if r := recover(); r != nil {
+                    panics <- r
+                }
+            }()
+            for i := range jobs {
+                args := []reflect.Value{reflect.ValueOf(items[i])}
+                results[i] = expr.Call(args)[0].Interface()
+            }
+        }()
+    }
+
+    // Set once every element has been handed to a worker
+    fed := false
+    go func() {
+        defer close(jobs)
+        for i := range items {
+            select {
+            case jobs <- i:
+            case <-stop:
+                return
+            case <-ctx.Done():
+                return
+            }
+        }
+        fed = true
+    }()
+
+    done := make(chan struct{})
+    go func() {
+        wg.Wait()
+        close(done)
+    }()
+
+    select {
+    case <-done:
+    case r := <-panics:
+        panic(r)
+    case <-ctx.Done():
+        return nil, ctx.Err()
+    }
+    // A worker may have panicked just before the rest finished
+    select {
+    case r := <-panics:
+        panic(r)
+    default:
+    }
+    if !fed {
+        return nil, ctx.Err()
+    }
+    return results, nil
+}
+
+/*
+   Scan lazily yields every intermediate accumulator of a left fold: first
+   memo itself, then the accumulator after folding in each element in turn.
+   The final element is the same value Reduce would return, and the result
+   is always one element longer than the input. The reducer is called as
+   f(acc, x), just like Reduce.
+
+   Example:
+       list := List(1, 2, 3)
+       totals := list.Scan(func(acc, x int) int { return acc + x }, 0) // => [0, 1, 3, 6]
+*/
+func (list *LinkedList) Scan(f Anything, memo Anything) *LinkedList {
+    return Cons(memo, list.ScanExclusive(f, memo))
+}
+
+/*
+   ScanExclusive lazily yields the running accumulator of a left fold,
+   emitting the accumulator after each element is folded in. Unlike Scan,
+   the seed itself is never emitted, so the result always has the same
+   length as the input. The reducer is called as f(acc, x),
+   just like Reduce.
+
+   Example:
+       list := List(1, 2, 3)
+       totals := list.ScanExclusive(func(acc, x int) int { return acc + x }, 0) // => [1, 3, 6]
+*/
+func (list *LinkedList) ScanExclusive(f Anything, seed Anything) *LinkedList {
+    expr := reflect.ValueOf(f)
+    var scanned LinkedList
+    scanned = func() *Node {
+        node := (*list)()
+        if node != nil {
+            args := []reflect.Value{reflect.ValueOf(seed), reflect.ValueOf(node.Head)}
+            acc := expr.Call(args)[0].Interface()
+            return &Node{acc, node.Tail.ScanExclusive(f, acc)}
+        }
+        return nil
+    }
+    return &scanned
+}
+
+/*
+   Memoize caches the results of a pure function f, keyed on its
+   arguments, so that calling the result again with the same arguments
+   returns the cached value rather than calling f again. The cache is
+   safe for concurrent use.
+
+   Arguments are compared as map keys where their values allow it. If any
+   argument isn't comparable, such as a slice, a map, or a struct holding
+   one in an interface field, the arguments are keyed on their printed
+   representation instead. A nil argument is passed to f as the zero value
+   of its parameter.
+
+   Example:
+       var fib Function
+       fib = Memoize(func(n int) int {
+           if n < 2 {
+               return n
+           }
+           return fib(n-1).(int) + fib(n-2).(int)
+       })
+       fib(80) // => 23416728348467685, without the exponential blowup
+*/
+func Memoize(f Anything) Function {
+    return memoize(f, func(args []Anything) Anything {
+        for _, arg := range args {
+            if arg != nil && !reflect.ValueOf(arg).Comparable() {
+                return fmt.Sprintf("%#v", args)
+            }
+        }
+        return cacheKey(args, false)
+    })
+}
+
+/*
+   Once returns a function which calls f the first time it is called, and
+   from then on returns the result of that first call. Arguments given to
+   any call after the first are ignored. It is safe to call concurrently;
+   callers racing the first call wait for it to finish.
+
+   Example:
+       config := Once(loadConfig)
+       config("app.yml") // loads the file
+       config("other.yml") // => the config loaded from app.yml
+*/
+func Once(f Anything) Function {
+    fn := reflect.ValueOf(f)
+    var once sync.Once
+    var result Anything
+
+    var called Function
+    called = func(args ...Anything) Anything {
+        once.Do(func() {
+            result = fn.Call(AnythingToValues(args))[0].Interface()
+        })
+        return result
+    }
+
+    return called
+}
+
+/*
+   MemoizeListArg caches the results of f, keyed on its arguments. Unlike
+   Memoize, any argument which is a *LinkedList is keyed on its contents
+   rather than its identity, so two structurally equal lists hit the same
+   cache entry. The cache is safe for concurrent use.
+
+   Because list arguments are materialized in order to build the key, this
+   must not be used with infinite lists. Every argument, and every element
+   of a list argument, is used as part of a map key, and so must be
+   hashable; calling the memoized function with unhashable values panics.
+
+   Example:
+       sum := MemoizeListArg(func(list *LinkedList) int {
+           return list.Reduce(func(acc, x int) int { return acc + x }, 0).(int)
+       })
+       sum(List(1, 2, 3)) // computed => 6
+       sum(List(1, 2, 3)) // cached => 6
+*/
+func MemoizeListArg(f Anything) Function {
+    return memoize(f, func(args []Anything) Anything {
+        return cacheKey(args, true)
+    })
+}
+
+// memoize caches the results of f using the cache key derived by key
+func memoize(f Anything, key func([]Anything) Anything) Function {
+    fn := reflect.ValueOf(f)
+    var mutex sync.Mutex
+    cache := make(map[Anything]Anything)
+
+    var memoized Function
+    memoized = func(args ...Anything) Anything {
+        k := key(args)
+        mutex.Lock()
+        val, ok := cache[k]
+        mutex.Unlock()
+        if ok {
+            return val
+        }
+        values := make([]reflect.Value, len(args))
+        for i, arg := range args {
+            values[i] = argValue(fn.Type(), i, arg)
+        }
+        // The lock isn't held during the call, so f may recurse into memoized
+        val = fn.Call(values)[0].Interface()
+        mutex.Lock()
+        cache[k] = val
+        mutex.Unlock()
+        return val
+    }
+
+    return memoized
+}
+
+// anythingType is the reflected type of Anything
+var anythingType = reflect.TypeOf((*Anything)(nil)).Elem()
+
+// listArgMarker precedes the elements of a list argument within a cache key
+type listArgMarker struct {
+    length int
+}
+
+/*
+   cacheKey combines a set of arguments into a single comparable value, an
+   array of Anything, which can be used as a map key. When expandLists is
+   set, *LinkedList arguments contribute their elements rather than their
+   identity.
+*/
+func cacheKey(args []Anything, expandLists bool) Anything {
+    parts := make([]Anything, 0, len(args))
+    for _, arg := range args {
+        if list, ok := arg.(*LinkedList); ok && expandLists {
+            elements := ToSlice(list)
+            parts = append(parts, listArgMarker{len(elements)})
+            parts = append(parts, elements...)
+            continue
+        }
+        parts = append(parts, arg)
+    }
+    key := reflect.New(reflect.ArrayOf(len(parts), anythingType)).Elem()
+    for i, part := range parts {
+        if part != nil {
+            key.Index(i).Set(reflect.ValueOf(part))
+        }
+    }
+    return key.Interface()
+}
+
+/*
+   Pluck lazily extracts the value of the named exported field from each
+   element of the list. Elements may be structs or pointers to structs.
+   An element which doesn't have the field causes a panic when it is
+   forced.
+
+   Example:
+       type User struct { Name string }
+       list := List(User{"Ann"}, &User{"Bob"})
+       names := list.Pluck("Name") // => [Ann, Bob]
+*/
+func (list *LinkedList) Pluck(fieldName string) *LinkedList {
+    var plucked LinkedList
+    plucked = func() *Node {
+        node := (*list)()
+        if node != nil {
+            return &Node{structField(node.Head, fieldName, "Pluck").Interface(), node.Tail.Pluck(fieldName)}
+        }
+        return nil
+    }
+    return &plucked
+}
+
+// structField returns the named field of a struct, or pointer to a struct, value
+func structField(element Anything, fieldName, caller string) reflect.Value {
+    val := reflect.ValueOf(element)
+    if val.Kind() == reflect.Ptr && !val.IsNil() {
+        val = val.Elem()
+    }
+    if val.Kind() == reflect.Struct {
+        if field, ok := val.Type().FieldByName(fieldName); ok && field.PkgPath == "" {
+            return val.FieldByIndex(field.Index)
+        }
+    }
+    panic(fmt.Sprintf("Attempted to call %s on an element of type %T, which has no exported field %q.", caller, element, fieldName))
+}
+
+/*
+   PluckMethod lazily calls the named method on each element of the list,
+   collecting the results. The method must take no arguments and return a
+   single value. An element which doesn't have such a method causes a
+   panic when it is forced.
+
+   Example:
+       list := List(time.Second, time.Minute)
+       strs := list.PluckMethod("String") // => [1s, 1m0s]
+*/
+func (list *LinkedList) PluckMethod(methodName string) *LinkedList {
+    var plucked LinkedList
+    plucked = func() *Node {
+        node := (*list)()
+        if node != nil {
+            var method reflect.Value
+            if val := reflect.ValueOf(node.Head); val.IsValid() {
+                method = val.MethodByName(methodName)
+            }
+            if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+                panic(fmt.Sprintf("Attempted to call PluckMethod on an element of type %T, which has no method %s() with a single return value.", node.Head, methodName))
+            }
+            return &Node{method.Call(nil)[0].Interface(), node.Tail.PluckMethod(methodName)}
+        }
+        return nil
+    }
+    return &plucked
+}
+
+/*
+   DistinctLast returns a new list containing only the last occurrence of
+   each distinct element. The surviving elements keep their relative
+   order, so an element appears at the position of its final occurrence
+   in the input: List(1, 2, 1, 3).DistinctLast() is [2, 1, 3], whereas
+   Distinct, which keeps first occurrences, gives [1, 2, 3].
+
+   Comparable elements are compared with ==, while anything else (slices,
+   maps, etc.) falls back to reflect.DeepEqual. Since it has to see the
+   whole list before it knows which occurrence is last, this is strict,
+   and will loop forever on an infinite list.
+
+   Example:
+       events := List("a", "b", "a", "c", "b")
+       latest := events.DistinctLast() // => [a, c, b]
+*/
+func (list *LinkedList) DistinctLast() *LinkedList {
+    elements := ToSlice(list)
+    seen := newSeenSet()
+    result := Empty
+    // Walk backwards, so the first time we see an element is its last occurrence
+    for i := len(elements) - 1; i >= 0; i-- {
+        if seen.add(elements[i]) {
+            result = Cons(elements[i], result)
+        }
+    }
+    return result
+}
+
+/*
+   seenSet tracks a set of elements, using a map for comparable elements
+   and falling back to a linear scan with reflect.DeepEqual for the rest.
+*/
+type seenSet struct {
+    keys   map[Anything]bool
+    others []Anything
+}
+
+func newSeenSet() *seenSet {
+    return &seenSet{keys: make(map[Anything]bool)}
+}
+
+// add inserts element into the set, reporting whether it wasn't already present
+func (set *seenSet) add(element Anything) bool {
+    if element == nil || reflect.ValueOf(element).Comparable() {
+        if set.keys[element] {
+            return false
+        }
+        set.keys[element] = true
+        return true
+    }
+    for _, other := range set.others {
+        if reflect.DeepEqual(element, other) {
+            return false
+        }
+    }
+    set.others = append(set.others, element)
+    return true
+}
+
+/*
+   FromScanner creates a lazy list of the tokens read from s, as returned
+   by s.Text(). Tokens are split however the scanner has been configured
+   (lines by default, or words, runes, or a custom bufio.SplitFunc), and
+   are only read from the underlying input as the list is forced, so huge
+   inputs are streamed rather than loaded into memory.
+
+   Each node is read at most once and then cached, so the list may be
+   traversed repeatedly, but the scanner itself is consumed. The list ends
+   at end of input or on the first read error; as with any bufio.Scanner,
+   call s.Err() once the list is exhausted to tell the two apart.
+
+   Example:
+       s := bufio.NewScanner(strings.NewReader("the quick brown fox"))
+       s.Split(bufio.ScanWords)
+       words := FromScanner(s) // => [the, quick, brown, fox]
+       if err := s.Err(); err != nil { ... }
+*/
+func FromScanner(s *bufio.Scanner) *LinkedList {
+    return lazy(func() *Node {
+        if s.Scan() {
+            return &Node{s.Text(), FromScanner(s)}
+        }
+        return nil
+    })
+}
+
+/*
+   FromChannel creates a lazy list of the values received from ch, ending
+   when the channel is closed. Values are only received as the list is
+   forced, so forcing a node may block until the next value is sent.
+
+   Receiving from a channel is destructive, so each value can only be read
+   once. Each node is cached the first time it is forced, which means the
+   list itself can be traversed repeatedly, but the values it consumed are
+   gone from the channel, and a second FromChannel over the same channel
+   won't see them.
+
+   Example:
+       words := FromChannel(ch).Map(strings.ToUpper)
+*/
+func FromChannel(ch <-chan Anything) *LinkedList {
+    return lazy(func() *Node {
+        if v, ok := <-ch; ok {
+            return &Node{v, FromChannel(ch)}
+        }
+        return nil
+    })
+}
+
+/*
+   lazy creates a list from a thunk which is evaluated at most once, with
+   the resulting Node cached for every subsequent force. This makes lists
+   backed by a stateful source consistent no matter how often they are
+   traversed, and it's safe to force such a list from several goroutines
+   at once. If the thunk panics, nothing is cached, and the next force
+   tries again.
+*/
+func lazy(thunk func() *Node) *LinkedList {
+    var mutex sync.Mutex
+    var done bool
+    var node *Node
+    var list LinkedList
+    list = func() *Node {
+        mutex.Lock()
+        defer mutex.Unlock()
+        // Unlike sync.Once, a panic leaves done unset, rather than caching nil
+        if !done {
+            node = thunk()
+            done = true
+        }
+        return node
+    }
+    return &list
+}
+
+/*
+   MapIndices lazily applies f to the elements whose zero-based index
+   satisfies pred, a func(int) bool, leaving every other element as-is.
+
+   Example:
+       list := List(1, 2, 3, 4)
+       evens := func(i int) bool { return i%2 == 0 }
+       negated := list.MapIndices(evens, func(x int) int { return -x }) // => [-1, 2, -3, 4]
+*/
+func (list *LinkedList) MapIndices(pred Anything, f Anything) *LinkedList {
+    return mapIndicesFrom(list, 0, reflect.ValueOf(pred), reflect.ValueOf(f))
+}
+
+// mapIndicesFrom implements MapIndices, for a list whose first element is at index
+func mapIndicesFrom(list *LinkedList, index int, pred, expr reflect.Value) *LinkedList {
+    var mapped LinkedList
+    mapped = func() *Node {
+        node := (*list)()
+        if node != nil {
+            head := node.Head
+            if pred.Call([]reflect.Value{reflect.ValueOf(index)})[0].Bool() {
+                head = expr.Call([]reflect.Value{reflect.ValueOf(node.Head)})[0].Interface()
+            }
+            return &Node{head, mapIndicesFrom(node.Tail, index+1, pred, expr)}
+        }
+        return nil
+    }
+    return &mapped
+}
+
+/*
+   Last returns the final element of the list, and true, or nil and false
+   if the list is empty. It has to walk the whole list to find the end, so
+   it must only be called on a finite list. Together with Init it mirrors
+   Head and Tail, deconstructing the list from the other end.
+
+   Example:
+       last, ok := List(1, 2, 3).Last() // => 3, true
+*/
+func (list *LinkedList) Last() (Anything, bool) {
+    node := (*list)()
+    if node == nil {
+        return nil, false
+    }
+    for next := (*node.Tail)(); next != nil; next = (*next.Tail)() {
+        node = next
+    }
+    return node.Head, true
+}
+
+/*
+   Init lazily yields every element of the list except the last one. It
+   looks only one element ahead of the consumer, so it stays lazy: the
+   final element is simply withheld once the end of the list is reached.
+   On an empty or single element list the result is empty.
+
+   Example:
+       list := List(1, 2, 3)
+       init := list.Init() // => [1, 2]
+*/
+func (list *LinkedList) Init() *LinkedList {
+    var init LinkedList
+    init = func() *Node {
+        node := (*list)()
+        if node != nil {
+            return (*initFrom(node))()
+        }
+        return nil
+    }
+    return &init
+}
+
+// initFrom implements Init, for a list whose first node has already been forced
+func initFrom(node *Node) *LinkedList {
+    var init LinkedList
+    init = func() *Node {
+        next := (*node.Tail)()
+        if next != nil {
+            return &Node{node.Head, initFrom(next)}
+        }
+        return nil
+    }
+    return &init
+}
+
+/*
+   CartesianProduct lazily yields every pair []Anything{x, y}, for each x
+   in the list and each y in other, with x varying slowest. The receiver
+   may be infinite, but other must be finite, since it is re-traversed in
+   full for every element of the receiver.
+
+   Example:
+       pairs := List(1, 2).CartesianProduct(List("a", "b"))
+       // => [[1 a], [1 b], [2 a], [2 b]]
+*/
+func (list *LinkedList) CartesianProduct(other *LinkedList) *LinkedList {
+    var product LinkedList
+    product = func() *Node {
+        // With nothing to pair against, there's no need to walk the receiver
+        if (*other)() == nil {
+            return nil
+        }
+        node := (*list)()
+        if node != nil {
+            return (*productFrom(node.Head, other, node.Tail, other))()
+        }
+        return nil
+    }
+    return &product
+}
+
+// productFrom pairs x with what remains of other, before moving on to the next x
+func productFrom(x Anything, remaining, rest, other *LinkedList) *LinkedList {
+    var product LinkedList
+    product = func() *Node {
+        node := (*remaining)()
+        if node != nil {
+            return &Node{[]Anything{x, node.Head}, productFrom(x, node.Tail, rest, other)}
+        }
+        return (*rest.CartesianProduct(other))()
+    }
+    return &product
+}
+
+/*
+   GroupByMulti groups the elements of the list by several keys at once,
+   producing a tree of nested maps. The elements are grouped by the first
+   key function, then each of those groups is grouped by the second, and
+   so on. Every level except the last is a map[Anything]Anything of keys
+   to the next level down, and the last level maps keys to a *LinkedList
+   of the elements (in their original order) which share the full path of
+   keys.
+
+   Keys are used as map keys, and so must be comparable. This forces the
+   whole list, so it must only be used on finite lists.
+
+   Example:
+       type Sale struct { Region, Product string }
+       tree := sales.GroupByMulti([]Anything{
+           func(s Sale) string { return s.Region },
+           func(s Sale) string { return s.Product },
+       })
+       tree["EU"].(map[Anything]Anything)["Widget"] // => a *LinkedList of EU widget sales
+*/
+func (list *LinkedList) GroupByMulti(keyFns []Anything) map[Anything]Anything {
+    if len(keyFns) == 0 {
+        panic("Attempted to call GroupByMulti without any key functions.")
+    }
+    return groupLevels(ToSlice(list), AnythingToValues(keyFns))
+}
+
+// groupLevels groups elements by the first of keyFns, recursing for the rest
+func groupLevels(elements []Anything, keyFns []reflect.Value) map[Anything]Anything {
+    buckets := groupBuckets(elements, keyFns[0])
+    result := make(map[Anything]Anything, len(buckets))
+    for key, members := range buckets {
+        if len(keyFns) == 1 {
+            result[key] = ToList(members)
+        } else {
+            result[key] = groupLevels(members, keyFns[1:])
+        }
+    }
+    return result
+}
+
+/*
+   SwapPairs lazily swaps the two components of each pair in the list, so
+   that every []Anything{a, b} becomes []Anything{b, a}, and every
+   Pair{a, b} becomes Pair{b, a}. An element which is neither a Pair nor a
+   two element []Anything causes a panic when it is forced.
+
+   Example:
+       list := List([]Anything{0, "a"}, []Anything{1, "b"})
+       swapped := list.SwapPairs() // => [[a 0], [b 1]]
+*/
+func (list *LinkedList) SwapPairs() *LinkedList {
+    var swapped LinkedList
+    swapped = func() *Node {
+        node := (*list)()
+        if node != nil {
+            if pair, ok := node.Head.(Pair); ok {
+                return &Node{Pair{pair.Second, pair.First}, node.Tail.SwapPairs()}
+            }
+            pair, ok := node.Head.([]Anything)
+            if !ok || len(pair) != 2 {
+                panic(fmt.Sprintf("Attempted to call SwapPairs on an element of type %T. Must be a Pair or a two element []Anything.", node.Head))
+            }
+            return &Node{[]Anything{pair[1], pair[0]}, node.Tail.SwapPairs()}
+        }
+        return nil
+    }
+    return &swapped
+}
+
+/*
+   TakeUntilStable lazily yields elements until one is equal to its
+   predecessor, according to eq(prev, curr) bool, at which point the
+   sequence has stabilized. The stable element is included, and nothing
+   after it is forced. This makes it a natural way to bound the steps of
+   a fixed-point iteration, which may otherwise be infinite.
+
+   Example:
+       steps := Generate(100, func(x int) int { return x / 2 })
+       converged := steps.TakeUntilStable(func(a, b int) bool { return a == b })
+       // => [100, 50, 25, 12, 6, 3, 1, 0, 0]
+*/
+func (list *LinkedList) TakeUntilStable(eq Anything) *LinkedList {
+    var taken LinkedList
+    taken = func() *Node {
+        node := (*list)()
+        if node != nil {
+            return &Node{node.Head, stableFrom(node.Head, node.Tail, reflect.ValueOf(eq))}
+        }
+        return nil
+    }
+    return &taken
+}
+
+// stableFrom implements TakeUntilStable, for the elements following prev
+func stableFrom(prev Anything, list *LinkedList, eq reflect.Value) *LinkedList {
+    var taken LinkedList
+    taken = func() *Node {
+        node := (*list)()
+        if node != nil {
+            args := []reflect.Value{reflect.ValueOf(prev), reflect.ValueOf(node.Head)}
+            if eq.Call(args)[0].Bool() {
+                return &Node{node.Head, Empty}
+            }
+            return &Node{node.Head, stableFrom(node.Head, node.Tail, eq)}
+        }
+        return nil
+    }
+    return &taken
+}
+
+/*
+   BatchChannel streams the elements of the list through the returned
+   channel in batches of up to size elements, using a channel buffer of
+   the given capacity. The list is forced lazily by a background goroutine,
+   one element at a time, and the final partial batch (if any) is sent
+   before the channel is closed.
+
+   If ctx is cancelled, the goroutine stops and closes the channel without
+   sending anything further, so cancel the context whenever the consumer
+   stops reading early, or the goroutine will block forever. A size of
+   less than 1 causes a panic.
+
+   Example:
+       for batch := range records.BatchChannel(ctx, 100, 1) {
+           db.InsertAll(batch)
+       }
+*/
+func (list *LinkedList) BatchChannel(ctx context.Context, size, buffer int) <-chan []Anything {
+    if size < 1 {
+        panic("Attempted to call BatchChannel with a size less than 1.")
+    }
+    ch := make(chan []Anything, buffer)
+    go func() {
+        defer close(ch)
+        send := func(batch []Anything) bool {
+            select {
+            case ch <- batch:
+                return true
+            case <-ctx.Done():
+                return false
+            }
+        }
+        batch := make([]Anything, 0, size)
+        for node := (*list)(); node != nil; node = (*node.Tail)() {
+            if ctx.Err() != nil {
+                return
+            }
+            batch = append(batch, node.Head)
+            if len(batch) == size {
+                if !send(batch) {
+                    return
+                }
+                batch = make([]Anything, 0, size)
+            }
+        }
+        if len(batch) > 0 {
+            send(batch)
+        }
+    }()
+    return ch
+}
+
+/*
+   Over lazily returns a copy of each element of the list, with the named
+   exported field replaced by the result of calling f on its old value.
+   The elements themselves are never modified. Elements may be structs, in
+   which case the copies are structs, or pointers to structs, in which case
+   the copies are pointers to new structs. An element which doesn't have
+   the field causes a panic when it is forced.
+
+   Example:
+       type Counter struct { Name string; Hits int }
+       list := List(Counter{"a", 1}, Counter{"b", 2})
+       bumped := list.Over("Hits", func(n int) int { return n + 1 }) // => [{a 2}, {b 3}]
+*/
+func (list *LinkedList) Over(fieldName string, f Anything) *LinkedList {
+    expr := reflect.ValueOf(f)
+    var updated LinkedList
+    updated = func() *Node {
+        node := (*list)()
+        if node != nil {
+            old := structField(node.Head, fieldName, "Over")
+            replacement := expr.Call([]reflect.Value{old})[0]
+
+            original := reflect.ValueOf(node.Head)
+            isPtr := original.Kind() == reflect.Ptr
+            if isPtr {
+                original = original.Elem()
+            }
+            copied := reflect.New(original.Type()).Elem()
+            copied.Set(original)
+            copied.FieldByName(fieldName).Set(replacement)
+
+            head := copied.Interface()
+            if isPtr {
+                head = copied.Addr().Interface()
+            }
+            return &Node{head, node.Tail.Over(fieldName, f)}
+        }
+        return nil
+    }
+    return &updated
+}
+
+/*
+   Replace lazily yields the list with every element which is
+   reflect.DeepEqual to old replaced by replacement.
+
+   Example:
+       list := List(1, 0, 2, 0)
+       replaced := list.Replace(0, -1) // => [1, -1, 2, -1]
+*/
+func (list *LinkedList) Replace(old, replacement Anything) *LinkedList {
+    var replaced LinkedList
+    replaced = func() *Node {
+        node := (*list)()
+        if node != nil {
+            head := node.Head
+            if reflect.DeepEqual(head, old) {
+                head = replacement
+            }
+            return &Node{head, node.Tail.Replace(old, replacement)}
+        }
+        return nil
+    }
+    return &replaced
+}
+
+/*
+   ReplaceFirst is like Replace, but only replaces the first element which
+   is reflect.DeepEqual to old. Everything after that element is passed
+   through untouched.
+
+   Example:
+       list := List(1, 0, 2, 0)
+       replaced := list.ReplaceFirst(0, -1) // => [1, -1, 2, 0]
+*/
+func (list *LinkedList) ReplaceFirst(old, replacement Anything) *LinkedList {
+    var replaced LinkedList
+    replaced = func() *Node {
+        node := (*list)()
+        if node != nil {
+            if reflect.DeepEqual(node.Head, old) {
+                return &Node{replacement, node.Tail}
+            }
+            return &Node{node.Head, node.Tail.ReplaceFirst(old, replacement)}
+        }
+        return nil
+    }
+    return &replaced
+}
+
+/*
+   Coalesce returns the first element of the list which isn't nil, along
+   with true, or (nil, false) if there is no such element. Typed nils,
+   such as a nil pointer, map, slice, channel or function stored in an
+   Anything, are treated as nil too. This stops at the first non-nil
+   element, so it is safe on an infinite list which contains one.
+
+   Example:
+       var missing *Config
+       list := List(nil, missing, defaults)
+       config, ok := list.Coalesce() // => defaults, true
+*/
+func (list *LinkedList) Coalesce() (Anything, bool) {
+    for node := (*list)(); node != nil; node = (*node.Tail)() {
+        if !isNil(node.Head) {
+            return node.Head, true
+        }
+    }
+    return nil, false
+}
+
+// isNil reports whether value is nil, or a typed nil boxed in an Anything
+func isNil(value Anything) bool {
+    if value == nil {
+        return true
+    }
+    val := reflect.ValueOf(value)
+    switch val.Kind() {
+    case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.Interface, reflect.UnsafePointer:
+        return val.IsNil()
+    }
+    return false
+}
+
+/*
+   Shuffle returns a new list with the elements of the list in a random
+   order. The order is determined entirely by seed, so shuffling the same
+   list with the same seed always produces the same result. This forces
+   the whole list, so it must only be used on finite lists.
+
+   Example:
+       list := List(1, 2, 3, 4)
+       shuffled := list.Shuffle(42)
+*/
+func (list *LinkedList) Shuffle(seed int64) *LinkedList {
+    elements := ToSlice(list)
+    random := rand.New(rand.NewSource(seed))
+    random.Shuffle(len(elements), func(i, j int) {
+        elements[i], elements[j] = elements[j], elements[i]
+    })
+    return ToList(elements)
+}
+
+/*
+   Permutations lazily yields every permutation of the list, each as a
+   *LinkedList, using Heap's algorithm. Permutations are generated on
+   demand, so the first few can be taken without computing the rest, but
+   bear in mind that a list of n elements has n! permutations; that is
+   already over three million for a list of 10. The empty list has exactly
+   one permutation, the empty list. The input is forced in full as soon as
+   the first permutation is, so it must be finite.
+
+   Example:
+       perms := List(1, 2, 3).Permutations()
+       // => [[1, 2, 3], [2, 1, 3], [3, 1, 2], [1, 3, 2], [2, 3, 1], [3, 2, 1]]
+*/
+func (list *LinkedList) Permutations() *LinkedList {
+    var perms LinkedList
+    perms = func() *Node {
+        elements := ToSlice(list)
+        return &Node{ToList(elements), heapPermutations(elements, make([]int, len(elements)), 0)}
+    }
+    return &perms
+}
+
+/*
+   heapPermutations continues Heap's algorithm from the given state. The
+   state is copied before it's advanced, so forcing the same node twice
+   always yields the same permutation.
+*/
+func heapPermutations(elements []Anything, counters []int, i int) *LinkedList {
+    var perms LinkedList
+    perms = func() *Node {
+        perm := append([]Anything(nil), elements...)
+        c := append([]int(nil), counters...)
+        for k := i; k < len(perm); {
+            if c[k] < k {
+                if k%2 == 0 {
+                    perm[0], perm[k] = perm[k], perm[0]
+                } else {
+                    perm[c[k]], perm[k] = perm[k], perm[c[k]]
+                }
+                c[k]++
+                return &Node{ToList(perm), heapPermutations(perm, c, 0)}
+            }
+            c[k] = 0
+            k++
+        }
+        return nil
+    }
+    return &perms
+}
+
+/*
+   DistinctByHash lazily yields the elements of the list whose key, as
+   computed by hashFn, hasn't been seen before. Keys must be comparable
+   (typically a string or int derived from the element), but the elements
+   themselves needn't be, which makes this suitable for deduping structs
+   containing slices, or slices themselves.
+
+   Distinct elements are emitted as soon as they are encountered, so this
+   works on infinite lists, but every key seen is remembered until the
+   list is no longer referenced, so memory grows with the number of
+   distinct keys.
+
+   Example:
+       list := List([]int{1, 2}, []int{2, 1}, []int{1, 2})
+       distinct := list.DistinctByHash(func(xs []int) string { return fmt.Sprint(xs) })
+       // => [[1 2], [2 1]]
+*/
+func (list *LinkedList) DistinctByHash(hashFn Anything) *LinkedList {
+    return distinctByHash(list, reflect.ValueOf(hashFn), make(map[Anything]bool))
+}
+
+/*
+   distinctByHash implements DistinctByHash. Each node is memoized, so that
+   the shared seen set is only ever updated once per element, in order.
+*/
+func distinctByHash(list *LinkedList, expr reflect.Value, seen map[Anything]bool) *LinkedList {
+    return lazy(func() *Node {
+        for node := (*list)(); node != nil; node = (*node.Tail)() {
+            key := expr.Call([]reflect.Value{reflect.ValueOf(node.Head)})[0].Interface()
+            if !seen[key] {
+                seen[key] = true
+                return &Node{node.Head, distinctByHash(node.Tail, expr, seen)}
+            }
+        }
+        return nil
+    })
+}
+
+/*
+   ZipPad lazily pairs up the elements of the list and other, as
+   []Anything{x, y}, continuing until both lists are exhausted. Once one
+   of the lists runs out, fill is used in its place. Since the result is
+   as long as the longer list, it is only finite if both lists are.
+
+   Example:
+       zipped := List(1, 2, 3).ZipPad(List("a"), nil) // => [[1 a], [2 <nil>], [3 <nil>]]
+*/
+func (list *LinkedList) ZipPad(other *LinkedList, fill Anything) *LinkedList {
+    var zipped LinkedList
+    zipped = func() *Node {
+        node, otherNode := (*list)(), (*other)()
+        if node == nil && otherNode == nil {
+            return nil
+        }
+        x, y := fill, fill
+        rest, otherRest := Empty, Empty
+        if node != nil {
+            x, rest = node.Head, node.Tail
+        }
+        if otherNode != nil {
+            y, otherRest = otherNode.Head, otherNode.Tail
+        }
+        return &Node{[]Anything{x, y}, rest.ZipPad(otherRest, fill)}
+    }
+    return &zipped
+}
+
+/*
+   ForEachBatch walks the list, calling f with each consecutive batch of
+   size elements, as a []Anything. The final batch may be smaller. Any
+   value returned by f is discarded. This is strict, so like Length it
+   will loop forever on an infinite list. A size of less than 1 causes a
+   panic.
+
+   Example:
+       records.ForEachBatch(100, func(batch []Anything) { api.Upload(batch) })
+*/
+func (list *LinkedList) ForEachBatch(size int, f Anything) {
+    if size < 1 {
+        panic("Attempted to call ForEachBatch with a size less than 1.")
+    }
+    expr := reflect.ValueOf(f)
+    batch := make([]Anything, 0, size)
+    for node := (*list)(); node != nil; node = (*node.Tail)() {
+        batch = append(batch, node.Head)
+        if len(batch) == size {
+            expr.Call([]reflect.Value{reflect.ValueOf(batch)})
+            batch = make([]Anything, 0, size)
+        }
+    }
+    if len(batch) > 0 {
+        expr.Call([]reflect.Value{reflect.ValueOf(batch)})
+    }
+}
+
+/*
+   Push returns a new list with v on top, leaving the original untouched.
+   It's an alias for Cons, for when a list is being used as a stack.
+
+   Example:
+       stack := Empty.Push(1).Push(2) // => [2, 1]
+*/
+func (list *LinkedList) Push(v Anything) *LinkedList {
+    return Cons(v, list)
+}
+
+/*
+   Pop returns the top of the list along with the rest of it, and true,
+   or (nil, Empty, false) if the list is empty. The original list is left
+   untouched, so it can still be used after popping.
+
+   Example:
+       stack := List(2, 1)
+       top, rest, ok := stack.Pop() // => 2, [1], true
+*/
+func (list *LinkedList) Pop() (Anything, *LinkedList, bool) {
+    node := (*list)()
+    if node == nil {
+        return nil, Empty, false
+    }
+    return node.Head, node.Tail, true
+}
+
+/*
+   A Queue is a persistent first-in, first-out queue built from two lists:
+   one holding the front of the queue, and one holding the back in reverse.
+   Enqueue and Dequeue never modify a Queue, they return a new one, so
+   older versions of a queue remain valid. When the front runs out, the
+   back is reversed to become the new front, which gives amortized O(1)
+   Enqueue and Dequeue when each version of the queue is used only once.
+
+   The zero value is an empty queue.
+
+   Example:
+       q := NewQueue(1, 2).Enqueue(3)
+       first, q, ok := q.Dequeue() // => 1, [2, 3], true
+*/
+type Queue struct {
+    front *LinkedList
+    back  *LinkedList
+}
+
+// NewQueue creates a Queue holding the given elements, in order
+func NewQueue(elements ...Anything) *Queue {
+    return &Queue{ToList(elements), Empty}
+}
+
+// Enqueue returns a new Queue with v added to the back
+func (q *Queue) Enqueue(v Anything) *Queue {
+    front, back := q.lists()
+    return &Queue{front, Cons(v, back)}
+}
+
+/*
+   Dequeue returns the element at the front of the queue, a new Queue
+   without it, and true, or (nil, q, false) if the queue is empty.
+*/
+func (q *Queue) Dequeue() (Anything, *Queue, bool) {
+    front, back := q.lists()
+    if (*front)() == nil {
+        // Reverse the back of the queue to form the new front
+        front, back = back.Reverse(), Empty
+    }
+    node := (*front)()
+    if node == nil {
+        return nil, q, false
+    }
+    return node.Head, &Queue{node.Tail, back}, true
+}
+
+// Length returns the number of elements in the queue
+func (q *Queue) Length() int {
+    front, back := q.lists()
+    return front.Length() + back.Length()
+}
+
+/*
+   Render a queue like a list, from front to back, e.g. [1, 2, 3]
+*/
+func (q *Queue) String() string {
+    elements := []Anything{}
+    for rest := q; ; {
+        v, next, ok := rest.Dequeue()
+        if !ok {
+            break
+        }
+        elements = append(elements, v)
+        rest = next
+    }
+    return ToList(elements).String()
+}
+
+// lists returns the front and back of the queue, treating nil as Empty
+func (q *Queue) lists() (*LinkedList, *LinkedList) {
+    front, back := q.front, q.back
+    if front == nil {
+        front = Empty
+    }
+    if back == nil {
+        back = Empty
+    }
+    return front, back
+}
+
+/*
+   RunningMax lazily yields, at each position, the largest element seen so
+   far, as determined by less(a, b) bool. When two elements compare equal,
+   the earlier one is kept.
+
+   Example:
+       less := func(a, b int) bool { return a < b }
+       List(3, 1, 4, 1, 5).RunningMax(less) // => [3, 3, 4, 4, 5]
+*/
+func (list *LinkedList) RunningMax(less Anything) *LinkedList {
+    return runningExtreme(list, reflect.ValueOf(less), true)
+}
+
+/*
+   RunningMin lazily yields, at each position, the smallest element seen
+   so far, as determined by less(a, b) bool. When two elements compare
+   equal, the earlier one is kept.
+
+   Example:
+       less := func(a, b int) bool { return a < b }
+       List(3, 1, 4, 1, 5).RunningMin(less) // => [3, 1, 1, 1, 1]
+*/
+func (list *LinkedList) RunningMin(less Anything) *LinkedList {
+    return runningExtreme(list, reflect.ValueOf(less), false)
+}
+
+// runningExtreme implements RunningMax (when max is set) and RunningMin
+func runningExtreme(list *LinkedList, less reflect.Value, max bool) *LinkedList {
+    var running LinkedList
+    running = func() *Node {
+        node := (*list)()
+        if node != nil {
+            return &Node{node.Head, extremeFrom(node.Head, node.Tail, less, max)}
+        }
+        return nil
+    }
+    return &running
+}
+
+// extremeFrom continues a running extreme, given the best element so far
+func extremeFrom(best Anything, list *LinkedList, less reflect.Value, max bool) *LinkedList {
+    var running LinkedList
+    running = func() *Node {
+        node := (*list)()
+        if node != nil {
+            args := []reflect.Value{reflect.ValueOf(node.Head), reflect.ValueOf(best)}
+            if max {
+                args[0], args[1] = args[1], args[0]
+            }
+            next := best
+            if less.Call(args)[0].Bool() {
+                next = node.Head
+            }
+            return &Node{next, extremeFrom(next, node.Tail, less, max)}
+        }
+        return nil
+    }
+    return &running
+}
+
+/*
+   TakeWhileIndexed lazily yields elements for as long as
+   pred(index, element) bool returns true, stopping at the first element
+   for which it returns false. Indexes are zero-based. Nothing after the
+   first failing element is forced, so this terminates on an infinite list
+   once the predicate fails.
+
+   Example:
+       list := List(1, 2, 3, 10, 4)
+       taken := list.TakeWhileIndexed(func(i, x int) bool { return i < 3 || x < 10 }) // => [1, 2, 3]
+*/
+func (list *LinkedList) TakeWhileIndexed(pred Anything) *LinkedList {
+    return takeWhileIndexedFrom(list, 0, reflect.ValueOf(pred))
+}
+
+// takeWhileIndexedFrom implements TakeWhileIndexed, for a list whose first element is at index
+func takeWhileIndexedFrom(list *LinkedList, index int, pred reflect.Value) *LinkedList {
+    var taken LinkedList
+    taken = func() *Node {
+        node := (*list)()
+        if node != nil {
+            args := []reflect.Value{reflect.ValueOf(index), reflect.ValueOf(node.Head)}
+            if pred.Call(args)[0].Bool() {
+                return &Node{node.Head, takeWhileIndexedFrom(node.Tail, index+1, pred)}
+            }
+        }
+        return nil
+    }
+    return &taken
+}
+
+/*
+   MapShared lazily maps f over the list like Map, but shares structure
+   with the original list wherever f leaves elements as they were, judged
+   by reflect.DeepEqual. An unchanged element keeps its original value,
+   rather than the equal copy f returned. Nothing is forced ahead of the
+   consumer, so this is as lazy as Map, and works on infinite lists and
+   streaming sources alike.
+
+   Sharing nodes as well as values needs to know that every element after
+   a point maps to itself, which is only known once a traversal reaches the
+   end of the list. So the first traversal builds its nodes just as Map
+   does, while remembering which stretch of the list was unchanged up to
+   the end. Later traversals of the same result then continue straight
+   into the original nodes from that point on, without calling f or
+   allocating anything more. For transforms which leave most of a list as
+   it was, such as normalizing data which is mostly already normalized,
+   this saves most of the work of traversing the result again.
+
+   Example:
+       list := List("A", "b", "c")
+       lowered := list.MapShared(strings.ToLower) // => [a, b, c]
+       lowered.String() // a second traversal continues with the original [b, c]
+*/
+func (list *LinkedList) MapShared(f Anything) *LinkedList {
+    return mapShared(list, reflect.ValueOf(f), &sharedRun{})
+}
+
+// sharedRun is a stretch of consecutive elements which MapShared left unchanged
+type sharedRun struct {
+    mutex sync.Mutex
+    // Set once a traversal reaches the end of the list without leaving the run
+    clean bool
+}
+
+// sharedStep is what MapShared remembers about one position of its result
+type sharedStep struct {
+    mapped LinkedList
+    mutex  sync.Mutex
+    // The run this element belongs to, if it was left unchanged
+    run *sharedRun
+    // The rest of the result, kept so that its steps are remembered too
+    next *LinkedList
+}
+
+// mapShared implements MapShared with an already reflected f, for an element in run
+func mapShared(list *LinkedList, expr reflect.Value, run *sharedRun) *LinkedList {
+    step := &sharedStep{}
+    step.mapped = func() *Node {
+        step.mutex.Lock()
+        member := step.run
+        step.mutex.Unlock()
+        if member != nil {
+            member.mutex.Lock()
+            clean := member.clean
+            member.mutex.Unlock()
+            if clean {
+                // Everything from here to the end is unchanged, so share it
+                return (*list)()
+            }
+        }
+
+        node := (*list)()
+        if node == nil {
+            run.mutex.Lock()
+            run.clean = true
+            run.mutex.Unlock()
+            return nil
+        }
+        head := expr.Call([]reflect.Value{reflect.ValueOf(node.Head)})[0].Interface()
+        unchanged := reflect.DeepEqual(head, node.Head)
+        if unchanged {
+            head = node.Head
+        }
+
+        step.mutex.Lock()
+        defer step.mutex.Unlock()
+        if unchanged {
+            step.run = run
+        }
+        if step.next == nil {
+            // A changed element ends the run, so its successors start a new one
+            tailRun := run
+            if !unchanged {
+                tailRun = &sharedRun{}
+            }
+            step.next = mapShared(node.Tail, expr, tailRun)
+        }
+        return &Node{head, step.next}
+    }
+    return &step.mapped
+}
+
+/*
+   ChunkByWeight lazily groups consecutive elements into sub-lists whose
+   total weight, as given by weightFn(element), doesn't exceed maxWeight.
+   A new chunk is started whenever adding the next element would take the
+   current one over the limit. The weight may be of any numeric type.
+
+   A single element which weighs more than maxWeight on its own can't fit
+   in any chunk, so it is given a chunk of its own rather than being
+   dropped. Each chunk is built when it is forced, so this works on
+   infinite lists when combined with Take.
+
+   Example:
+       payloads := List("aaaa", "bb", "cc", "dddddd", "e")
+       chunks := payloads.ChunkByWeight(5, func(s string) int { return len(s) })
+       // => [[aaaa], [bb, cc], [dddddd], [e]]
+*/
+func (list *LinkedList) ChunkByWeight(maxWeight float64, weightFn Anything) *LinkedList {
+    expr := reflect.ValueOf(weightFn)
+    weigh := func(element Anything) float64 {
+        return toFloat64(expr.Call([]reflect.Value{reflect.ValueOf(element)})[0], "ChunkByWeight")
+    }
+    var chunked LinkedList
+    chunked = func() *Node {
+        node := (*list)()
+        if node == nil {
+            return nil
+        }
+        // The first element always starts the chunk, however heavy it is
+        total := weigh(node.Head)
+        members := []Anything{node.Head}
+        rest := node.Tail
+        for next := (*rest)(); next != nil; next = (*rest)() {
+            weight := weigh(next.Head)
+            if total+weight > maxWeight {
+                break
+            }
+            total += weight
+            members = append(members, next.Head)
+            rest = next.Tail
+        }
+        return &Node{ToList(members), rest.ChunkByWeight(maxWeight, weightFn)}
+    }
+    return &chunked
+}
+
+// toFloat64 converts a reflected numeric value to a float64
+func toFloat64(val reflect.Value, caller string) float64 {
+    switch val.Kind() {
+    case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+        return float64(val.Int())
+    case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+        return float64(val.Uint())
+    case reflect.Float32, reflect.Float64:
+        return val.Float()
+    case reflect.Interface:
+        if !val.IsNil() {
+            return toFloat64(val.Elem(), caller)
+        }
+    }
+    panic(fmt.Sprintf("Attempted to call %s with a value of kind %s. Must be numeric.", caller, val.Kind()))
+}
+
+/*
+   Decorate lazily pairs each element of the list with a key computed from
+   it, yielding []Anything{keyFn(element), element}. This is the first
+   step of the decorate-sort-undecorate idiom: the key is computed once per
+   element, the pairs are sorted or grouped by key, and Undecorate strips
+   the keys off again.
+
+   Example:
+       words := List("banana", "kiwi", "apple")
+       decorated := words.Decorate(func(s string) int { return len(s) })
+       // => [[6 banana], [4 kiwi], [5 apple]]
+*/
+func (list *LinkedList) Decorate(keyFn Anything) *LinkedList {
+    expr := reflect.ValueOf(keyFn)
+    var decorated LinkedList
+    decorated = func() *Node {
+        node := (*list)()
+        if node != nil {
+            key := expr.Call([]reflect.Value{reflect.ValueOf(node.Head)})[0].Interface()
+            return &Node{[]Anything{key, node.Head}, node.Tail.Decorate(keyFn)}
+        }
+        return nil
+    }
+    return &decorated
+}
+
+/*
+   Undecorate lazily reverses Decorate, yielding just the element from each
+   []Anything{key, element} pair. An element which isn't a two element
+   []Anything causes a panic when it is forced.
+
+   Example:
+       words := decorated.Undecorate() // => [banana, kiwi, apple]
+*/
+func (list *LinkedList) Undecorate() *LinkedList {
+    var undecorated LinkedList
+    undecorated = func() *Node {
+        node := (*list)()
+        if node != nil {
+            pair, ok := node.Head.([]Anything)
+            if !ok || len(pair) != 2 {
+                panic(fmt.Sprintf("Attempted to call Undecorate on an element of type %T. Must be a two element []Anything.", node.Head))
+            }
+            return &Node{pair[1], node.Tail.Undecorate()}
+        }
+        return nil
+    }
+    return &undecorated
+}
+
+/*
+   IsSorted reports whether the list is in non-decreasing order according
+   to less(a, b) bool, that is, whether there is no adjacent pair of
+   elements where the second is less than the first. It stops at the first
+   such pair, so it can return false on an infinite list, but returning
+   true requires walking to the end of the list.
+
+   Example:
+       less := func(a, b int) bool { return a < b }
+       List(1, 2, 2, 3).IsSorted(less) // => true
+       List(1, 3, 2).IsSorted(less)    // => false
+*/
+func (list *LinkedList) IsSorted(less Anything) bool {
+    expr := reflect.ValueOf(less)
+    node := (*list)()
+    if node == nil {
+        return true
+    }
+    for next := (*node.Tail)(); next != nil; next = (*next.Tail)() {
+        args := []reflect.Value{reflect.ValueOf(next.Head), reflect.ValueOf(node.Head)}
+        if expr.Call(args)[0].Bool() {
+            return false
+        }
+        node = next
+    }
+    return true
+}
+
+/*
+   DistinctCount returns the number of distinct elements in the list, in a
+   single pass and without building an intermediate list. Comparable
+   elements are tracked in a map, which is fast; anything else (slices,
+   maps, etc.) falls back to a linear scan with reflect.DeepEqual, which
+   gets slow when there are many such elements. Like Length, this will
+   loop forever on an infinite list.
+
+   Example:
+       List(1, 2, 2, 3, 1).DistinctCount() // => 3
+*/
+func (list *LinkedList) DistinctCount() int {
+    seen := newSeenSet()
+    count := 0
+    for node := (*list)(); node != nil; node = (*node.Tail)() {
+        if seen.add(node.Head) {
+            count++
+        }
+    }
+    return count
+}
+
+/*
+   EnumerateFrom lazily pairs each element of the list with its position,
+   yielding []Anything{index, element}, where the index of the first
+   element is start and each following index is one greater. The start
+   may be negative. This is handy for 1-based numbering, or for continuing
+   the numbering from a previous list.
+
+   Example:
+       list := List("a", "b", "c")
+       numbered := list.EnumerateFrom(1) // => [[1 a], [2 b], [3 c]]
+*/
+func (list *LinkedList) EnumerateFrom(start int) *LinkedList {
+    var enumerated LinkedList
+    enumerated = func() *Node {
+        node := (*list)()
+        if node != nil {
+            return &Node{[]Anything{start, node.Head}, node.Tail.EnumerateFrom(start + 1)}
+        }
+        return nil
+    }
+    return &enumerated
+}
+
+/*
+   FlattenSlices treats each element of the list as a Go slice (or array),
+   and lazily concatenates their contents into a single flat list. Empty
+   slices contribute nothing. An element which isn't a slice or array is
+   not passed through; it causes a panic when it is reached. Only as many
+   of the outer elements are forced as are needed, so this works on
+   infinite lists when combined with Take.
+
+   Example:
+       list := List([]int{1, 2}, []int{}, []int{3})
+       flat := list.FlattenSlices() // => [1, 2, 3]
+*/
+func (list *LinkedList) FlattenSlices() *LinkedList {
+    var flattened LinkedList
+    flattened = func() *Node {
+        // Skip over any empty slices, without recursing
+        for node := (*list)(); node != nil; node = (*node.Tail)() {
+            val := reflect.ValueOf(node.Head)
+            if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
+                panic(fmt.Sprintf("Attempted to call FlattenSlices on an element of type %T. Must be Slice or Array.", node.Head))
+            }
+            if val.Len() > 0 {
+                return (*sliceFrom(val, 0, node.Tail))()
+            }
+        }
+        return nil
+    }
+    return &flattened
+}
+
+// sliceFrom yields the elements of slice from index i, then goes on to flatten rest
+func sliceFrom(slice reflect.Value, i int, rest *LinkedList) *LinkedList {
+    var flattened LinkedList
+    flattened = func() *Node {
+        if i < slice.Len() {
+            return &Node{slice.Index(i).Interface(), sliceFrom(slice, i+1, rest)}
+        }
+        return (*rest.FlattenSlices())()
+    }
+    return &flattened
+}
+
+/*
+   SortInterface copies the elements of the list into a SortableList,
+   which implements sort.Interface using less(a, b) bool. This gives full
+   control over how the list is sorted, for example choosing between
+   sort.Sort and sort.Stable. The list itself is never modified; once the
+   copy is sorted, call List on it to get the result as a new LinkedList.
+   This forces the whole list, so it must only be used on finite lists.
+
+   Example:
+       sortable := list.SortInterface(func(a, b int) bool { return a < b })
+       sort.Stable(sortable)
+       sorted := sortable.List()
+*/
+func (list *LinkedList) SortInterface(less Anything) *SortableList {
+    return &SortableList{ToSlice(list), LessFunc(less)}
+}
+
+// SortableList is a sort.Interface over a copy of a LinkedList, see SortInterface
+type SortableList struct {
+    elements []Anything
+    less     func(a, b Anything) bool
+}
+
+var _ sort.Interface = (*SortableList)(nil)
+
+// Len is the number of elements
+func (s *SortableList) Len() int {
+    return len(s.elements)
+}
+
+// Less reports whether element i sorts before element j
+func (s *SortableList) Less(i, j int) bool {
+    return s.less(s.elements[i], s.elements[j])
+}
+
+// Swap swaps elements i and j
+func (s *SortableList) Swap(i, j int) {
+    s.elements[i], s.elements[j] = s.elements[j], s.elements[i]
+}
+
+// List returns the elements, in their current order, as a new LinkedList
+func (s *SortableList) List() *LinkedList {
+    return ToList(append([]Anything(nil), s.elements...))
+}
+
+/*
+   SegmentReduce splits the list into segments and lazily yields the result
+   of folding each one. A new segment starts at every element for which
+   boundary(element) bool is true (and at the start of the list). Within a
+   segment, elements are folded with f(acc, element), starting from seed,
+   and the accumulator is emitted once the next boundary, or the end of the
+   list, is reached. Each segment is folded when it is forced, so this works
+   on infinite lists when combined with Take, as long as every segment is
+   finite.
+
+   Example:
+       // Sum the values of each section, where sections start at a zero
+       list := List(0, 1, 2, 0, 3, 0, 4, 5)
+       isZero := func(x int) bool { return x == 0 }
+       sums := list.SegmentReduce(isZero, func(acc, x int) int { return acc + x }, 0) // => [3, 3, 9]
+*/
+func (list *LinkedList) SegmentReduce(boundary Anything, f Anything, seed Anything) *LinkedList {
+    pred := reflect.ValueOf(boundary)
+    expr := reflect.ValueOf(f)
+    var reduced LinkedList
+    reduced = func() *Node {
+        node := (*list)()
+        if node == nil {
+            return nil
+        }
+        // The first element always belongs to the segment, boundary or not
+        acc := expr.Call([]reflect.Value{reflect.ValueOf(seed), reflect.ValueOf(node.Head)})[0].Interface()
+        rest := node.Tail
+        for next := (*rest)(); next != nil; next = (*rest)() {
+            if pred.Call([]reflect.Value{reflect.ValueOf(next.Head)})[0].Bool() {
+                break
+            }
+            acc = expr.Call([]reflect.Value{reflect.ValueOf(acc), reflect.ValueOf(next.Head)})[0].Interface()
+            rest = next.Tail
+        }
+        return &Node{acc, rest.SegmentReduce(boundary, f, seed)}
+    }
+    return &reduced
+}
+
+/*
+   Quantile returns the exact q-quantile (0 <= q <= 1) of a list of numeric
+   elements, along with true, or (0, false) if the list is empty. When the
+   quantile falls between two elements, it is linearly interpolated between
+   them, so Quantile(0.5) of [1, 2, 3, 4] is 2.5. The elements may be of any
+   numeric types, but anything else causes a panic, as does a q outside of
+   [0, 1].
+
+   This is exact, but it copies every element into memory in order to sort
+   them, so it needs memory proportional to the length of the list. For very
+   long lists, QuantileApprox gives an estimate in constant memory.
+
+   Example:
+       median, ok := List(3, 1, 4, 1, 5).Quantile(0.5) // => 3, true
+*/
+func (list *LinkedList) Quantile(q float64) (float64, bool) {
+    checkQuantile(q, "Quantile")
+    var values []float64
+    for node := (*list)(); node != nil; node = (*node.Tail)() {
+        values = append(values, toFloat64(reflect.ValueOf(node.Head), "Quantile"))
+    }
+    if len(values) == 0 {
+        return 0, false
+    }
+    return exactQuantile(values, q), true
+}
+
+/*
+   QuantileApprox estimates the q-quantile (0 <= q <= 1) of a list of
+   numeric elements, using the P² algorithm of Jain and Chlamtac. It reads
+   the list in a single pass and keeps only five markers, so unlike
+   Quantile it needs constant memory however long the list is, which makes
+   it suitable for huge lists (or a Take of an infinite one). The price is
+   that the result is an estimate, which is usually close for smooth
+   distributions with plenty of elements. On five or fewer elements the
+   result is exact. Beyond that, for small lists and an extreme q (such
+   as 0.01 or 0.99) the markers have had little time to spread out, so
+   the estimate is heavily biased towards the median; use Quantile when
+   the list is small enough to hold. It returns (0, false) on an empty
+   list, and panics on non-numeric elements or a q outside of [0, 1].
+
+   Example:
+       p99, ok := latencies.QuantileApprox(0.99)
+*/
+func (list *LinkedList) QuantileApprox(q float64) (float64, bool) {
+    checkQuantile(q, "QuantileApprox")
+    // Marker heights, actual positions, desired positions, and their increments
+    var heights [5]float64
+    positions := [5]float64{1, 2, 3, 4, 5}
+    desired := [5]float64{1, 1 + 2*q, 1 + 4*q, 3 + 2*q, 5}
+    increments := [5]float64{0, q / 2, q, (1 + q) / 2, 1}
+
+    count := 0
+    for node := (*list)(); node != nil; node = (*node.Tail)() {
+        x := toFloat64(reflect.ValueOf(node.Head), "QuantileApprox")
+        if count < 5 {
+            // The first five observations seed the markers
+            heights[count] = x
+            count++
+            if count == 5 {
+                sort.Float64s(heights[:])
+            }
+            continue
+        }
+        count++
+
+        // Find the cell containing x, widening the extremes if need be
+        var k int
+        switch {
+        case x < heights[0]:
+            heights[0] = x
+            k = 0
+        case x >= heights[4]:
+            heights[4] = x
+            k = 3
+        default:
+            for k = 0; k < 3 && x >= heights[k+1]; k++ {
+            }
+        }
+        for i := k + 1; i < 5; i++ {
+            positions[i]++
+        }
+        for i := range desired {
+            desired[i] += increments[i]
+        }
+
+        // Nudge the middle markers towards their desired positions
+        for i := 1; i < 4; i++ {
+            d := desired[i] - positions[i]
+            if (d >= 1 && positions[i+1]-positions[i] > 1) || (d <= -1 && positions[i-1]-positions[i] < -1) {
+                step := math.Copysign(1, d)
+                height := parabolicHeight(heights, positions, i, step)
+                if heights[i-1] < height && height < heights[i+1] {
+                    heights[i] = height
+                } else {
+                    j := i + int(step)
+                    heights[i] += step * (heights[j] - heights[i]) / (positions[j] - positions[i])
+                }
+                positions[i] += step
+            }
+        }
+    }
+
+    if count == 0 {
+        return 0, false
+    }
+    if count <= 5 {
+        // The markers haven't moved yet, so they are exactly the elements
+        return exactQuantile(heights[:count], q), true
+    }
+    return heights[2], true
+}
+
+// parabolicHeight is the P² piecewise-parabolic prediction for moving marker i by step
+func parabolicHeight(heights, positions [5]float64, i int, step float64) float64 {
+    below := positions[i] - positions[i-1]
+    above := positions[i+1] - positions[i]
+    return heights[i] + step/(positions[i+1]-positions[i-1])*
+        ((below+step)*(heights[i+1]-heights[i])/above+(above-step)*(heights[i]-heights[i-1])/below)
+}
+
+// exactQuantile linearly interpolates the q-quantile of values, sorting them in place
+func exactQuantile(values []float64, q float64) float64 {
+    sort.Float64s(values)
+    rank := q * float64(len(values)-1)
+    lower := int(math.Floor(rank))
+    if lower+1 >= len(values) {
+        return values[len(values)-1]
+    }
+    return values[lower] + (rank-float64(lower))*(values[lower+1]-values[lower])
+}
+
+// checkQuantile panics unless q is a valid quantile
+func checkQuantile(q float64, caller string) {
+    if !(q >= 0 && q <= 1) {
+        panic(fmt.Sprintf("Attempted to call %s with a quantile of %v. Must be between 0 and 1.", caller, q))
+    }
+}
+
+/*
+   ToSortedSlice returns the elements of the list as a new slice, sorted
+   according to less(a, b) bool. The sort is stable, so elements which
+   compare equal keep the order they had in the list. This forces the
+   whole list, so it must only be used on finite lists.
+
+   Example:
+       list := List(3, 1, 2)
+       sorted := list.ToSortedSlice(func(a, b int) bool { return a < b }) // => [1 2 3]
+*/
+func (list *LinkedList) ToSortedSlice(less Anything) []Anything {
+    elements := ToSlice(list)
+    sort.SliceStable(elements, LessIndexFunc(less, elements))
+    return elements
+}
+
+/*
+   Concat lazily yields the elements of the list, followed by the elements
+   of other. Neither list is forced until its elements are needed, so the
+   other list may be infinite. If the receiver is infinite, the elements of
+   other are simply never reached.
+
+   Example:
+       list := List(1, 2).Concat(List(3, 4)) // => [1, 2, 3, 4]
+*/
+func (list *LinkedList) Concat(other *LinkedList) *LinkedList {
+    return concatFunc(list, func() *LinkedList { return other })
+}
+
+/*
+   Prepend returns a new list with value in front of the elements of the
+   list. It's Cons, written as a method.
+
+   Example:
+       list := List(2, 3).Prepend(1) // => [1, 2, 3]
+*/
+func (list *LinkedList) Prepend(value Anything) *LinkedList {
+    return Cons(value, list)
+}
+
+/*
+   Append lazily yields the elements of the list, followed by value. As
+   with Concat, the list is only forced as the result is walked, so
+   appending to a long list costs nothing up front. Appending to an
+   infinite list is allowed, but value is never reached.
+
+   Example:
+       list := List(1, 2).Append(3) // => [1, 2, 3]
+*/
+func (list *LinkedList) Append(value Anything) *LinkedList {
+    return list.Concat(List(value))
+}
+
+/*
+   ConcatFunc lazily yields the elements of the list, followed by the
+   elements of the list returned by contFn. The continuation is only built
+   once the end of the receiver is actually reached, and contFn is called
+   at most once however many times the result is traversed, so it's a
+   cheap way to attach an expensive fallback which may never be needed.
+
+   Example:
+       results := cached.ConcatFunc(func() *LinkedList { return fetchRemaining() })
+*/
+func (list *LinkedList) ConcatFunc(contFn func() *LinkedList) *LinkedList {
+    var once sync.Once
+    var cont *LinkedList
+    continuation := func() *LinkedList {
+        once.Do(func() { cont = contFn() })
+        return cont
+    }
+    return concatFunc(list, continuation)
+}
+
+// concatFunc implements ConcatFunc, sharing a single continuation between every node
+func concatFunc(list *LinkedList, continuation func() *LinkedList) *LinkedList {
+    var concatenated LinkedList
+    concatenated = func() *Node {
+        node := (*list)()
+        if node != nil {
+            return &Node{node.Head, concatFunc(node.Tail, continuation)}
+        }
+        return (*continuation())()
+    }
+    return &concatenated
+}
+
+/*
+   DownsampleByTime lazily buckets a list of timestamped elements into
+   fixed windows of time, yielding one aggregate per window. The timestamp
+   of each element is extracted with tsFn(element) time.Time, and every
+   window is aggregated with aggFn(elements []Anything), which receives the
+   elements falling in that window, in order.
+
+   Windows are aligned by flooring each timestamp to a multiple of window
+   with time.Time.Truncate (so, for example, 1 minute windows start on the
+   minute). The list is expected to be in timestamp order: a window ends at
+   the first element which falls in a different one, so windows with no
+   elements are simply skipped, rather than aggregated from nothing. Each
+   window is aggregated as it is forced, so this works on a streaming or
+   infinite list when combined with Take. A window of zero or less causes
+   a panic.
+
+   Example:
+       perMinute := samples.DownsampleByTime(time.Minute,
+           func(s Sample) time.Time { return s.At },
+           func(window []Anything) int { return len(window) })
+*/
+func (list *LinkedList) DownsampleByTime(window time.Duration, tsFn Anything, aggFn Anything) *LinkedList {
+    if window <= 0 {
+        panic("Attempted to call DownsampleByTime with a window of zero or less.")
+    }
+    timestamp := reflect.ValueOf(tsFn)
+    aggregate := reflect.ValueOf(aggFn)
+    bucket := func(element Anything) time.Time {
+        ts := timestamp.Call([]reflect.Value{reflect.ValueOf(element)})[0].Interface().(time.Time)
+        return ts.Truncate(window)
+    }
+
+    var downsampled LinkedList
+    downsampled = func() *Node {
+        node := (*list)()
+        if node == nil {
+            return nil
+        }
+        start := bucket(node.Head)
+        members := []Anything{node.Head}
+        rest := node.Tail
+        for next := (*rest)(); next != nil; next = (*rest)() {
+            if !bucket(next.Head).Equal(start) {
+                break
+            }
+            members = append(members, next.Head)
+            rest = next.Tail
+        }
+        head := aggregate.Call([]reflect.Value{reflect.ValueOf(members)})[0].Interface()
+        return &Node{head, rest.DownsampleByTime(window, tsFn, aggFn)}
+    }
+    return &downsampled
+}
+
+/*
+   Reverse returns a new list with the elements of the list in the opposite
+   order. Reversal has to reach the end of the list before it can yield
+   the first element, so this forces the whole list, and must only be
+   called on finite lists. Reversing an empty list gives an empty list.
+
+   Example:
+       list := List(1, 2, 3)
+       reversed := list.Reverse() // => [3, 2, 1]
+*/
+func (list *LinkedList) Reverse() *LinkedList {
+    result := Empty
+    for node := (*list)(); node != nil; node = (*node.Tail)() {
+        result = Cons(node.Head, result)
+    }
+    return result
+}
+
+/*
+   FlatMap maps f over the list, where f returns a *LinkedList for each
+   element, and lazily concatenates the resulting lists into one flat list.
+   Only as many elements are mapped as are needed, so FlatMap over an
+   infinite list composes with Take, as long as each sub-list is finite.
+
+   Example:
+       list := List(1, 2, 3)
+       repeated := list.FlatMap(func(x int) *LinkedList { return List(x, x) }) // => [1, 1, 2, 2, 3, 3]
+*/
+func (list *LinkedList) FlatMap(f Anything) *LinkedList {
+    expr := reflect.ValueOf(f)
+    return flattenWith(list, func(element Anything) *LinkedList {
+        result := expr.Call([]reflect.Value{reflect.ValueOf(element)})[0].Interface()
+        sublist, ok := result.(*LinkedList)
+        if !ok {
+            panic(fmt.Sprintf("Attempted to call FlatMap with a function returning %T. Must return *LinkedList.", result))
+        }
+        return sublist
+    })
+}
+
+/*
+   Flatten lazily concatenates a list of lists into a single list. Only one
+   level is flattened, so elements of the inner lists which are themselves
+   lists are left as they are. Each element of list must be a *LinkedList,
+   or Flatten panics when it reaches it. Only as many inner lists are
+   forced as are needed, so an infinite list of finite lists can be
+   flattened and combined with Take.
+
+   Example:
+       nested := List(List(1, 2), List(3), Empty, List(4))
+       flat := Flatten(nested) // => [1, 2, 3, 4]
+*/
+func Flatten(list *LinkedList) *LinkedList {
+    return flattenWith(list, func(element Anything) *LinkedList {
+        sublist, ok := element.(*LinkedList)
+        if !ok {
+            panic(fmt.Sprintf("Attempted to call Flatten on a list containing %T. Every element must be *LinkedList.", element))
+        }
+        return sublist
+    })
+}
+
+// flattenWith lazily concatenates the lists produced by calling toList on each element
+func flattenWith(list *LinkedList, toList func(Anything) *LinkedList) *LinkedList {
+    var flattened LinkedList
+    flattened = func() *Node {
+        // Skip over empty lists, without recursing
+        for node := (*list)(); node != nil; node = (*node.Tail)() {
+            if first := (*toList(node.Head))(); first != nil {
+                return &Node{first.Head, first.Tail.Concat(flattenWith(node.Tail, toList))}
+            }
+        }
+        return nil
+    }
+    return &flattened
+}
+
+/*
+   Find returns the first element of the list which satisfies the predicate,
+   along with true, or (nil, false) if no element does. It stops as soon as
+   a match is found, so it is safe on an infinite list which contains one.
+
+   Example:
+       list := List(1, 2, 3, 4)
+       even, ok := list.Find(func(x int) bool { return x%2 == 0 }) // => 2, true
+*/
+func (list *LinkedList) Find(f Anything) (Anything, bool) {
+    expr := reflect.ValueOf(f)
+    for node := (*list)(); node != nil; node = (*node.Tail)() {
+        args := []reflect.Value{reflect.ValueOf(node.Head)}
+        if expr.Call(args)[0].Bool() {
+            return node.Head, true
+        }
+    }
+    return nil, false
+}
+
+/*
+   Any reports whether at least one element of the list satisfies the
+   predicate. It stops at the first element that does, so it is safe on an
+   infinite list which contains one. Any of an empty list is false.
+
+   Example:
+       List(1, 2, 3).Any(func(x int) bool { return x > 2 }) // => true
+*/
+func (list *LinkedList) Any(f Anything) bool {
+    _, found := list.Find(f)
+    return found
+}
+
+/*
+   All reports whether every element of the list satisfies the predicate.
+   It stops at the first element that doesn't, but returning true requires
+   walking the whole list, so it only terminates on an infinite list when
+   the answer is false. All of an empty list is true.
+
+   Example:
+       List(1, 2, 3).All(func(x int) bool { return x > 0 }) // => true
+*/
+func (list *LinkedList) All(f Anything) bool {
+    expr := reflect.ValueOf(f)
+    for node := (*list)(); node != nil; node = (*node.Tail)() {
+        args := []reflect.Value{reflect.ValueOf(node.Head)}
+        if !expr.Call(args)[0].Bool() {
+            return false
+        }
+    }
+    return true
+}
+
+/*
+   Count returns the number of elements in the list which satisfy the
+   predicate. When f is nil every element is counted, just like Length.
+   This walks the whole list, so like Length, calling it on an infinite
+   list will cause an endless loop.
+
+   Example:
+       list := List(1, 2, 3, 4)
+       evens := list.Count(func(x int) bool { return x%2 == 0 }) // => 2
+*/
+func (list *LinkedList) Count(f Anything) int {
+    if f == nil {
+        return list.Length()
+    }
+    expr := reflect.ValueOf(f)
+    count := 0
+    for node := (*list)(); node != nil; node = (*node.Tail)() {
+        args := []reflect.Value{reflect.ValueOf(node.Head)}
+        if expr.Call(args)[0].Bool() {
+            count++
+        }
+    }
+    return count
+}
+
+/*
+   Contains reports whether any element of the list is equal to value,
+   using reflect.DeepEqual, so slices, maps and structs can be searched for
+   too. It stops at the first match, so it is safe on an infinite list
+   which contains the value.
+
+   Example:
+       List([]int{1}, []int{2}).Contains([]int{2}) // => true
+*/
+func (list *LinkedList) Contains(value Anything) bool {
+    for node := (*list)(); node != nil; node = (*node.Tail)() {
+        if reflect.DeepEqual(node.Head, value) {
+            return true
+        }
+    }
+    return false
+}
+
+/*
+   Nth returns the element at the given zero-based index, along with true,
+   or (nil, false) if the index is negative or past the end of the list.
+   Being a linked list, this is O(n), but it only walks as far as index, so
+   it is safe on an infinite list.
+
+   Example:
+       list := List("a", "b", "c")
+       second, ok := list.Nth(1) // => "b", true
+*/
+func (list *LinkedList) Nth(index int) (Anything, bool) {
+    if index < 0 {
+        return nil, false
+    }
+    node := (*list)()
+    for i := 0; node != nil; i++ {
+        if i == index {
+            return node.Head, true
+        }
+        node = (*node.Tail)()
+    }
+    return nil, false
+}
+
+/*
+   Head returns the first element of the list, along with true, or
+   (nil, false) if the list is empty.
+
+   Example:
+       first, ok := List(1, 2, 3).Head() // => 1, true
+*/
+func (list *LinkedList) Head() (Anything, bool) {
+    head, _, ok := list.Pop()
+    return head, ok
+}
+
+/*
+   Tail returns the rest of the list after the first element, which is
+   Empty if the list has one element or none.
+
+   Example:
+       rest := List(1, 2, 3).Tail() // => [2, 3]
+*/
+func (list *LinkedList) Tail() *LinkedList {
+    _, tail, _ := list.Pop()
+    return tail
+}
+
+// A Pair holds two values, such as the corresponding elements of two zipped lists
+type Pair struct {
+    First, Second Anything
+}
+
+/*
+   Zip lazily pairs up the corresponding elements of the list and other,
+   yielding a Pair{list[i], other[i]} for each index. It stops at the end of
+   the shorter list, so zipping an infinite list with a finite one yields
+   as many pairs as the finite list has elements.
+
+   Example:
+       zipped := List(1, 2, 3).Zip(List("a", "b")) // => [{1 a}, {2 b}]
+*/
+func (list *LinkedList) Zip(other *LinkedList) *LinkedList {
+    return zipWith(list, other, func(a, b Anything) Anything {
+        return Pair{a, b}
+    })
+}
+
+// zipWith lazily combines the corresponding elements of two lists, stopping at the shorter
+func zipWith(list, other *LinkedList, combine func(a, b Anything) Anything) *LinkedList {
+    var zipped LinkedList
+    zipped = func() *Node {
+        node := (*list)()
+        if node != nil {
+            otherNode := (*other)()
+            if otherNode != nil {
+                return &Node{combine(node.Head, otherNode.Head), zipWith(node.Tail, otherNode.Tail, combine)}
+            }
+        }
+        return nil
+    }
+    return &zipped
+}
+
+/*
+   ZipWith lazily combines the corresponding elements of the list and
+   other using f(a, b), stopping at the end of the shorter list.
+
+   Example:
+       sums := List(1, 2, 3).ZipWith(List(10, 20, 30), func(a, b int) int { return a + b })
+       // => [11, 22, 33]
+*/
+func (list *LinkedList) ZipWith(other *LinkedList, f Anything) *LinkedList {
+    expr := reflect.ValueOf(f)
+    return zipWith(list, other, func(a, b Anything) Anything {
+        args := []reflect.Value{reflect.ValueOf(a), reflect.ValueOf(b)}
+        return expr.Call(args)[0].Interface()
+    })
+}
+
+/*
+   Unzip reverses Zip, splitting a list of Pairs into a list of their First
+   values and a list of their Second values. Both lists are lazy, and each
+   walks the input independently, so either may be used without forcing
+   the other. An element which isn't a Pair causes a panic when it is
+   reached.
+
+   Example:
+       nums, letters := Unzip(List(1, 2).Zip(List("a", "b"))) // => [1, 2], [a, b]
+*/
+func Unzip(list *LinkedList) (*LinkedList, *LinkedList) {
+    firsts := list.Map(func(element Anything) Anything { return asPair(element, "Unzip").First })
+    seconds := list.Map(func(element Anything) Anything { return asPair(element, "Unzip").Second })
+    return firsts, seconds
+}
+
+// asPair asserts that element is a Pair, panicking with a clear message if not
+func asPair(element Anything, caller string) Pair {
+    pair, ok := element.(Pair)
+    if !ok {
+        panic(fmt.Sprintf("Attempted to call %s on an element of type %T. Must be Pair.", caller, element))
+    }
+    return pair
+}
+
+/*
+   Partition splits the list into two: the elements which satisfy the
+   predicate, and those which don't, each in their original order. Both
+   lists are lazy, and the predicate is called at most once per element,
+   however the two lists are traversed. Since finding the next element of
+   either list may mean walking past any number of elements belonging to
+   the other, this is really only suited to finite lists.
+
+   Example:
+       list := List(1, 2, 3, 4, 5)
+       evens, odds := list.Partition(func(x int) bool { return x%2 == 0 }) // => [2, 4], [1, 3, 5]
+*/
+func (list *LinkedList) Partition(f Anything) (*LinkedList, *LinkedList) {
+    tagged := tagMatches(list, reflect.ValueOf(f))
+    matching := tagged.Filter(func(p Pair) bool { return p.Second.(bool) })
+    rejected := tagged.Filter(func(p Pair) bool { return !p.Second.(bool) })
+    first := func(p Pair) Anything { return p.First }
+    return matching.Map(first), rejected.Map(first)
+}
+
+/*
+   tagMatches lazily pairs each element with the result of the predicate.
+   Each node is memoized, so lists which share it only call the predicate
+   once per element.
+*/
+func tagMatches(list *LinkedList, pred reflect.Value) *LinkedList {
+    return lazy(func() *Node {
+        node := (*list)()
+        if node != nil {
+            matches := pred.Call([]reflect.Value{reflect.ValueOf(node.Head)})[0].Bool()
+            return &Node{Pair{node.Head, matches}, tagMatches(node.Tail, pred)}
+        }
+        return nil
+    })
+}
+
+/*
+   Chunk lazily splits the list into consecutive sub-lists of size
+   elements each; the final chunk may be shorter. Each chunk is built when
+   it is forced, so chunking an infinite list gives an infinite list of
+   finite chunks, which can be combined with Take. A size of less than 1
+   causes a panic.
+
+   Example:
+       chunks := List(1, 2, 3, 4, 5).Chunk(2) // => [[1, 2], [3, 4], [5]]
+*/
+func (list *LinkedList) Chunk(size int) *LinkedList {
+    if size < 1 {
+        panic("Attempted to call Chunk with a size less than 1.")
+    }
+    var chunked LinkedList
+    chunked = func() *Node {
+        members := make([]Anything, 0, size)
+        rest := list
+        for len(members) < size {
+            node := (*rest)()
+            if node == nil {
+                break
+            }
+            members = append(members, node.Head)
+            rest = node.Tail
+        }
+        if len(members) == 0 {
+            return nil
+        }
+        return &Node{ToList(members), rest.Chunk(size)}
+    }
+    return &chunked
+}
+
+/*
+   Distinct lazily yields each distinct element of the list once, in the
+   order of its first appearance. Comparable elements are tracked in a map,
+   while anything else (slices, maps, etc.) falls back to a linear scan with
+   reflect.DeepEqual. Distinct elements are emitted as soon as they are
+   encountered, so this works on infinite lists, but every distinct element
+   seen is remembered, so memory grows with the number of them.
+
+   Example:
+       List(1, 2, 2, 3, 1).Distinct() // => [1, 2, 3]
+*/
+func (list *LinkedList) Distinct() *LinkedList {
+    return distinctFrom(list, newSeenSet())
+}
+
+/*
+   distinctFrom implements Distinct. Each node is memoized, so that the
+   shared seen set is only ever updated once per element, in order.
+*/
+func distinctFrom(list *LinkedList, seen *seenSet) *LinkedList {
+    return lazy(func() *Node {
+        for node := (*list)(); node != nil; node = (*node.Tail)() {
+            if seen.add(node.Head) {
+                return &Node{node.Head, distinctFrom(node.Tail, seen)}
+            }
+        }
+        return nil
+    })
+}
+
+/*
+   GroupBy groups the elements of the list by the key computed for each one
+   by keyFn, returning a map from each key to a list of the elements (in
+   their original order) which produced it. Keys are used as map keys, so
+   they must be comparable; a key which isn't causes a panic. This forces
+   the whole list, so it must only be used on finite lists.
+
+   Example:
+       list := List(1, 2, 3, 4, 5)
+       groups := list.GroupBy(func(x int) bool { return x%2 == 0 })
+       // => map[false:[1, 3, 5] true:[2, 4]]
+*/
+func (list *LinkedList) GroupBy(keyFn Anything) map[Anything]*LinkedList {
+    buckets := groupBuckets(ToSlice(list), reflect.ValueOf(keyFn))
+    result := make(map[Anything]*LinkedList, len(buckets))
+    for key, members := range buckets {
+        result[key] = ToList(members)
+    }
+    return result
+}
+
+// groupBuckets collects elements into slices by the key keyFn computes for each
+func groupBuckets(elements []Anything, keyFn reflect.Value) map[Anything][]Anything {
+    buckets := make(map[Anything][]Anything)
+    for _, element := range elements {
+        key := keyFn.Call([]reflect.Value{reflect.ValueOf(element)})[0].Interface()
+        buckets[key] = append(buckets[key], element)
+    }
+    return buckets
+}
+
+/*
+   SortBy returns a new list with the elements of the list sorted according
+   to less(a, b) bool. The sort is stable, so elements which compare equal
+   keep the order they had in the list. This forces the whole list, so it
+   must only be used on finite lists.
+
+   Example:
+       words := List("banana", "kiwi", "apple")
+       byLength := words.SortBy(func(a, b string) bool { return len(a) < len(b) })
+       // => [kiwi, apple, banana]
+*/
+func (list *LinkedList) SortBy(less Anything) *LinkedList {
+    return ToList(list.ToSortedSlice(less))
+}
+
+/*
+   Sort returns a new list with the elements of the list sorted in
+   ascending order. It works on lists of ordered primitives: integers,
+   floats or strings, all of the same kind. Anything else causes a panic.
+   Like SortBy, the sort is stable and forces the whole list.
+
+   Example:
+       List(3, 1, 2).Sort() // => [1, 2, 3]
+*/
+func (list *LinkedList) Sort() *LinkedList {
+    return list.SortBy(func(a, b Anything) bool {
+        return compareOrdered(a, b, "Sort") < 0
+    })
+}
+
+// compareOrdered compares two ordered primitives of the same kind, three-way
+func compareOrdered(a, b Anything, caller string) int {
+    x, y := reflect.ValueOf(a), reflect.ValueOf(b)
+    if x.Kind() != y.Kind() {
+        panic(fmt.Sprintf("Attempted to call %s on a list of mixed types %T and %T.", caller, a, b))
+    }
+    switch x.Kind() {
+    case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+        return compareWith(x.Int() < y.Int(), x.Int() > y.Int())
+    case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+        return compareWith(x.Uint() < y.Uint(), x.Uint() > y.Uint())
+    case reflect.Float32, reflect.Float64:
+        return compareWith(x.Float() < y.Float(), x.Float() > y.Float())
+    case reflect.String:
+        return compareWith(x.String() < y.String(), x.String() > y.String())
+    }
+    panic(fmt.Sprintf("Attempted to call %s on an element of type %T. Must be an integer, float or string.", caller, a))
+}
+
+// compareWith turns the results of < and > into a three-way comparison
+func compareWith(less, greater bool) int {
+    switch {
+    case less:
+        return -1
+    case greater:
+        return 1
+    }
+    return 0
+}
+
+/*
+   Sum returns the sum of a list of numbers, as the same type as its
+   elements. Every element must be of the same numeric type (int, int64,
+   float64, etc.), or Sum panics. The sum of an empty list is 0 (an int).
+   This forces the whole list, so it must only be used on finite lists.
+
+   Example:
+       List(1, 2, 3).Sum()   // => 6
+       List(0.5, 0.25).Sum() // => 0.75
+*/
+func (list *LinkedList) Sum() Anything {
+    return foldNumeric(list, "Sum", 0, func(acc, x reflect.Value) {
+        switch acc.Kind() {
+        case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+            acc.SetInt(acc.Int() + x.Int())
+        case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+            acc.SetUint(acc.Uint() + x.Uint())
+        default:
+            acc.SetFloat(acc.Float() + x.Float())
+        }
+    })
+}
+
+/*
+   Product returns the product of a list of numbers, as the same type as
+   its elements. Like Sum, every element must be of the same numeric type.
+   The product of an empty list is 1 (an int). This forces the whole list,
+   so it must only be used on finite lists.
+
+   Example:
+       List(2, 3, 4).Product() // => 24
+*/
+func (list *LinkedList) Product() Anything {
+    return foldNumeric(list, "Product", 1, func(acc, x reflect.Value) {
+        switch acc.Kind() {
+        case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+            acc.SetInt(acc.Int() * x.Int())
+        case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+            acc.SetUint(acc.Uint() * x.Uint())
+        default:
+            acc.SetFloat(acc.Float() * x.Float())
+        }
+    })
+}
+
+/*
+   Min returns the smallest of a list of numbers. Like Sum, every element
+   must be of the same numeric type. An empty list has no minimum, so it
+   causes a panic, just as a mixed list does. This forces the whole list, so
+   it must only be used on finite lists.
+
+   Example:
+       List(3, 1, 2).Min() // => 1
+*/
+func (list *LinkedList) Min() Anything {
+    if list.IsEmpty() {
+        panic("Attempted to call Min on an empty list.")
+    }
+    return foldNumeric(list, "Min", nil, func(acc, x reflect.Value) {
+        if compareOrdered(x.Interface(), acc.Interface(), "Min") < 0 {
+            acc.Set(x)
+        }
+    })
+}
+
+/*
+   Max returns the largest of a list of numbers. Like Sum, every element
+   must be of the same numeric type. An empty list has no maximum, so it
+   causes a panic, just as a mixed list does. This forces the whole list, so
+   it must only be used on finite lists.
+
+   Example:
+       List(3, 1, 2).Max() // => 3
+*/
+func (list *LinkedList) Max() Anything {
+    if list.IsEmpty() {
+        panic("Attempted to call Max on an empty list.")
+    }
+    return foldNumeric(list, "Max", nil, func(acc, x reflect.Value) {
+        if compareOrdered(x.Interface(), acc.Interface(), "Max") > 0 {
+            acc.Set(x)
+        }
+    })
+}
+
+/*
+   foldNumeric folds a list of numbers of a single type, starting from the
+   first element, with combine updating the accumulator in place. An empty
+   list gives empty.
+*/
+func foldNumeric(list *LinkedList, caller string, empty Anything, combine func(acc, x reflect.Value)) Anything {
+    node := (*list)()
+    if node == nil {
+        return empty
+    }
+    numType := reflect.TypeOf(node.Head)
+    if numType == nil || !isNumericKind(numType.Kind()) {
+        panic(fmt.Sprintf("Attempted to call %s on an element of type %T. Must be numeric.", caller, node.Head))
+    }
+    acc := reflect.New(numType).Elem()
+    acc.Set(reflect.ValueOf(node.Head))
+    for node = (*node.Tail)(); node != nil; node = (*node.Tail)() {
+        x := reflect.ValueOf(node.Head)
+        if !x.IsValid() || x.Type() != numType {
+            panic(fmt.Sprintf("Attempted to call %s on a list of mixed types %s and %T.", caller, numType, node.Head))
+        }
+        combine(acc, x)
+    }
+    return acc.Interface()
+}
+
+// isNumericKind reports whether kind is an integer or floating point kind
+func isNumericKind(kind reflect.Kind) bool {
+    switch kind {
+    case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+        reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+        reflect.Float32, reflect.Float64:
+        return true
+    }
+    return false
+}
+
+/*
+   Equal reports whether the list and other have the same length, and
+   reflect.DeepEqual elements at every position. It stops at the first
+   difference, so comparing an infinite list against a finite one (or one
+   which differs somewhere) returns, but two identical infinite lists will
+   be compared forever.
+
+   Example:
+       List(1, 2, 3).Equal(Range(1, 4, 1)) // => true
+*/
+func (list *LinkedList) Equal(other *LinkedList) bool {
+    node, otherNode := (*list)(), (*other)()
+    for node != nil && otherNode != nil {
+        if !reflect.DeepEqual(node.Head, otherNode.Head) {
+            return false
+        }
+        node, otherNode = (*node.Tail)(), (*otherNode.Tail)()
+    }
+    return node == nil && otherNode == nil
+}
+
+/*
+   Memoize returns a version of the list which caches each node the first
+   time it is forced, so traversing it again, or sharing it between several
+   pipelines, doesn't recompute anything. This is useful when the elements
+   are expensive to produce, or are produced with side effects. It's still
+   lazy: only the nodes which are actually forced are computed and cached.
+   It's safe to traverse the memoized list from several goroutines at once.
+
+   Example:
+       squares := Range(0, 1000, 1).Map(expensiveSquare).Memoize()
+       // Each square is computed once, however many lists share them
+       evens := squares.Filter(isEven)
+       odds := squares.Filter(isOdd)
+*/
+func (list *LinkedList) Memoize() *LinkedList {
+    return lazy(func() *Node {
+        node := (*list)()
+        if node != nil {
+            return &Node{node.Head, node.Tail.Memoize()}
+        }
+        return nil
+    })
+}
+
+/*
+   ToChannel starts a goroutine which walks the list, sending each element
+   on the returned channel, and closes the channel at the end of the list.
+   Elements are forced one at a time, as the consumer receives them.
+
+   The goroutine only exits once every element has been received, so if
+   the consumer may stop reading early, as it must with an infinite list,
+   the goroutine leaks. Use ToChannelCtx in that case.
+
+   Example:
+       for x := range List(1, 2, 3).ToChannel() {
+           fmt.Println(x)
+       }
+*/
+func (list *LinkedList) ToChannel() <-chan Anything {
+    return list.ToChannelCtx(context.Background())
+}
+
+/*
+   ToChannelCtx is like ToChannel, but stops sending and closes the channel
+   once ctx is cancelled, so the goroutine can be shut down when the
+   consumer is finished with the list.
+
+   Example:
+       ctx, cancel := context.WithCancel(context.Background())
+       defer cancel()
+       for x := range Repeat(1).ToChannelCtx(ctx) {
+           if done(x) {
+               break
+           }
+       }
+*/
+func (list *LinkedList) ToChannelCtx(ctx context.Context) <-chan Anything {
+    ch := make(chan Anything)
+    go func() {
+        defer close(ch)
+        for node := (*list)(); node != nil; node = (*node.Tail)() {
+            select {
+            case ch <- node.Head:
+            case <-ctx.Done():
+                return
+            }
+        }
+    }()
+    return ch
+}
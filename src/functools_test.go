@@ -0,0 +1,547 @@
+package functools
+
+import (
+    "context"
+    "math"
+    "reflect"
+    "runtime"
+    "strconv"
+    "strings"
+    "testing"
+    "time"
+)
+
+// channelList reads a list from ch, without memoizing, so each force receives again
+func channelList(ch chan int) *LinkedList {
+    var list LinkedList
+    list = func() *Node {
+        v, ok := <-ch
+        if !ok {
+            return nil
+        }
+        return &Node{v, channelList(ch)}
+    }
+    return &list
+}
+
+func TestFreezeWalksOnce(t *testing.T) {
+    ch := make(chan int, 3)
+    ch <- 1
+    ch <- 2
+    ch <- 3
+    close(ch)
+
+    frozen := channelList(ch).Freeze()
+    expected := []Anything{1, 2, 3}
+    if got := ToSlice(frozen); !reflect.DeepEqual(got, expected) {
+        t.Errorf("Freeze() = %v, expected %v", got, expected)
+    }
+    // Traversing again must not go back to the drained channel
+    if got := ToSlice(frozen); !reflect.DeepEqual(got, expected) {
+        t.Errorf("Freeze() second traversal = %v, expected %v", got, expected)
+    }
+}
+
+func TestFreezeCallsGeneratorOnce(t *testing.T) {
+    calls := 0
+    double := func(x int) int {
+        calls++
+        return x * 2
+    }
+    frozen := Generate(1, double).Take(3).Freeze()
+    ToSlice(frozen)
+
+    expected := []Anything{1, 2, 4}
+    if got := ToSlice(frozen); !reflect.DeepEqual(got, expected) {
+        t.Errorf("Freeze() = %v, expected %v", got, expected)
+    }
+    if calls > 3 {
+        t.Errorf("Freeze() called the generator %d times, expected at most 3", calls)
+    }
+}
+
+func TestLessFuncRejectsNil(t *testing.T) {
+    defer func() {
+        expected := "Attempted to call LessFunc with the wrong type of comparator. Must be func(a, b) bool."
+        if r := recover(); r != expected {
+            t.Errorf("LessFunc(nil) panicked with %v, expected %q", r, expected)
+        }
+    }()
+    LessFunc(nil)
+}
+
+// expectNoLeaks fails the test if the goroutine count doesn't settle back to before
+func expectNoLeaks(t *testing.T, before int) {
+    t.Helper()
+    deadline := time.Now().Add(time.Second)
+    for runtime.NumGoroutine() > before {
+        if time.Now().After(deadline) {
+            t.Errorf("leaked %d goroutines", runtime.NumGoroutine()-before)
+            return
+        }
+        time.Sleep(10 * time.Millisecond)
+    }
+}
+
+func TestPMapCtx(t *testing.T) {
+    before := runtime.NumGoroutine()
+    squared, err := Range(0, 100, 1).PMapCtx(context.Background(), 4, func(x int) int { return x * x })
+    if err != nil {
+        t.Fatalf("PMapCtx() returned error %v", err)
+    }
+    if got, _ := squared.Nth(99); got != 99*99 {
+        t.Errorf("PMapCtx() element 99 = %v, expected %v", got, 99*99)
+    }
+    expectNoLeaks(t, before)
+}
+
+func TestPMapCtxCancel(t *testing.T) {
+    before := runtime.NumGoroutine()
+    ctx, cancel := context.WithCancel(context.Background())
+    slow := func(x int) int {
+        time.Sleep(50 * time.Millisecond)
+        return x
+    }
+    time.AfterFunc(50*time.Millisecond, cancel)
+
+    start := time.Now()
+    result, err := Range(0, 100, 1).PMapCtx(ctx, 2, slow)
+    if elapsed := time.Since(start); elapsed > 200*time.Millisecond {
+        t.Errorf("PMapCtx() took %v to return after cancellation", elapsed)
+    }
+    if err != context.Canceled || result != nil {
+        t.Errorf("PMapCtx() = %v, %v, expected nil, %v", result, err, context.Canceled)
+    }
+    expectNoLeaks(t, before)
+}
+
+// expectList fails the test if list doesn't hold exactly the expected elements
+func expectList(t *testing.T, name string, list *LinkedList, expected ...Anything) {
+    t.Helper()
+    if expected == nil {
+        expected = []Anything{}
+    }
+    if got := ToSlice(list); !reflect.DeepEqual(got, expected) {
+        t.Errorf("%s = %v, expected %v", name, got, expected)
+    }
+}
+
+func TestDistinctLast(t *testing.T) {
+    list := List(1, 2, 1, 3)
+    expectList(t, "DistinctLast()", list.DistinctLast(), 2, 1, 3)
+    expectList(t, "Distinct()", list.Distinct(), 1, 2, 3)
+
+    events := List("a", "b", "a", "c", "b")
+    expectList(t, "DistinctLast()", events.DistinctLast(), "a", "c", "b")
+
+    slices := List([]int{1}, []int{2}, []int{1})
+    expectList(t, "DistinctLast() of slices", slices.DistinctLast(), []int{2}, []int{1})
+    expectList(t, "DistinctLast() of Empty", Empty.DistinctLast())
+}
+
+func TestComposeCtx(t *testing.T) {
+    double := func(x int) int { return x * 2 }
+    increment := func(x int) int { return x + 1 }
+    result, err := ComposeCtx(double, increment)(context.Background(), 3)
+    if result != 8 || err != nil {
+        t.Errorf("ComposeCtx() = %v, %v, expected 8, nil", result, err)
+    }
+}
+
+func TestComposeCtxCancelBetweenStages(t *testing.T) {
+    ctx, cancel := context.WithCancel(context.Background())
+    ran := false
+    last := func(x int) int {
+        ran = true
+        return x
+    }
+    cancelling := func(x int) int {
+        cancel()
+        return x
+    }
+    // Stages run right to left, so cancelling runs first
+    result, err := ComposeCtx(last, cancelling)(ctx, 1)
+    if err != context.Canceled || result != nil {
+        t.Errorf("ComposeCtx() = %v, %v, expected nil, %v", result, err, context.Canceled)
+    }
+    if ran {
+        t.Errorf("ComposeCtx() ran a stage after the context was cancelled")
+    }
+}
+
+func TestGroupByMultiTwoLevels(t *testing.T) {
+    type sale struct{ Region, Product string }
+    sales := List(
+        sale{"EU", "Widget"},
+        sale{"US", "Widget"},
+        sale{"EU", "Gadget"},
+        sale{"EU", "Widget"},
+    )
+    tree := sales.GroupByMulti([]Anything{
+        func(s sale) string { return s.Region },
+        func(s sale) string { return s.Product },
+    })
+
+    if len(tree) != 2 {
+        t.Fatalf("GroupByMulti() has %d regions, expected 2", len(tree))
+    }
+    eu := tree["EU"].(map[Anything]Anything)
+    if len(eu) != 2 {
+        t.Errorf("GroupByMulti() has %d EU products, expected 2", len(eu))
+    }
+    expectList(t, "EU widgets", eu["Widget"].(*LinkedList), sale{"EU", "Widget"}, sale{"EU", "Widget"})
+    expectList(t, "EU gadgets", eu["Gadget"].(*LinkedList), sale{"EU", "Gadget"})
+    us := tree["US"].(map[Anything]Anything)
+    expectList(t, "US widgets", us["Widget"].(*LinkedList), sale{"US", "Widget"})
+}
+
+func TestBatchChannelFinalPartialBatch(t *testing.T) {
+    before := runtime.NumGoroutine()
+    var batches [][]Anything
+    for batch := range Range(1, 6, 1).BatchChannel(context.Background(), 2, 0) {
+        batches = append(batches, batch)
+    }
+    expected := [][]Anything{{1, 2}, {3, 4}, {5}}
+    if !reflect.DeepEqual(batches, expected) {
+        t.Errorf("BatchChannel() = %v, expected %v", batches, expected)
+    }
+    expectNoLeaks(t, before)
+}
+
+func TestBatchChannelCancel(t *testing.T) {
+    before := runtime.NumGoroutine()
+    ctx, cancel := context.WithCancel(context.Background())
+    ch := Repeat(1).BatchChannel(ctx, 3, 0)
+    if batch := <-ch; len(batch) != 3 {
+        t.Errorf("BatchChannel() sent a batch of %d elements, expected 3", len(batch))
+    }
+    // The consumer walks away from an infinite list; cancelling must close the channel
+    cancel()
+    timeout := time.After(200 * time.Millisecond)
+    for closed := false; !closed; {
+        select {
+        case _, ok := <-ch:
+            closed = !ok
+        case <-timeout:
+            t.Fatalf("BatchChannel() didn't close the channel after cancellation")
+        }
+    }
+    expectNoLeaks(t, before)
+}
+
+func TestReplace(t *testing.T) {
+    list := List(1, 0, 2, 0)
+    expectList(t, "Replace() with several occurrences", list.Replace(0, -1), 1, -1, 2, -1)
+    expectList(t, "Replace() with no occurrences", list.Replace(9, -1), 1, 0, 2, 0)
+    expectList(t, "ReplaceFirst() with several occurrences", list.ReplaceFirst(0, -1), 1, -1, 2, 0)
+    expectList(t, "ReplaceFirst() with no occurrences", list.ReplaceFirst(9, -1), 1, 0, 2, 0)
+}
+
+func TestZipPad(t *testing.T) {
+    expectList(t, "ZipPad() with the receiver longer", List(1, 2, 3).ZipPad(List("a"), "-"),
+        []Anything{1, "a"}, []Anything{2, "-"}, []Anything{3, "-"})
+    expectList(t, "ZipPad() with other longer", List(1).ZipPad(List("a", "b", "c"), 0),
+        []Anything{1, "a"}, []Anything{0, "b"}, []Anything{0, "c"})
+    expectList(t, "ZipPad() of two empty lists", Empty.ZipPad(Empty, 0))
+}
+
+func TestPushPopPersistence(t *testing.T) {
+    stack := Empty.Push(1).Push(2)
+    pushed := stack.Push(3)
+    top, popped, ok := stack.Pop()
+    if top != 2 || !ok {
+        t.Errorf("Pop() = %v, %v, expected 2, true", top, ok)
+    }
+    expectList(t, "stack after Push and Pop", stack, 2, 1)
+    expectList(t, "pushed", pushed, 3, 2, 1)
+    expectList(t, "popped", popped, 1)
+
+    if top, rest, ok := Empty.Pop(); top != nil || rest != Empty || ok {
+        t.Errorf("Pop() of Empty = %v, %v, %v, expected nil, Empty, false", top, rest, ok)
+    }
+}
+
+func TestQueuePersistence(t *testing.T) {
+    q := NewQueue(1, 2).Enqueue(3)
+    longer := q.Enqueue(4)
+    first, rest, ok := q.Dequeue()
+    if first != 1 || !ok {
+        t.Errorf("Dequeue() = %v, %v, expected 1, true", first, ok)
+    }
+    // Dequeue from the shorter version again, after the reversal has happened once
+    rest.Dequeue()
+    rest = rest.Enqueue(5)
+
+    for _, c := range []struct {
+        name     string
+        queue    *Queue
+        expected string
+    }{
+        {"q", q, "[1, 2, 3]"},
+        {"longer", longer, "[1, 2, 3, 4]"},
+        {"rest", rest, "[2, 3, 5]"},
+        {"zero value", &Queue{}, "[]"},
+    } {
+        if got := c.queue.String(); got != c.expected {
+            t.Errorf("%s = %s, expected %s", c.name, got, c.expected)
+        }
+    }
+    if n := longer.Length(); n != 4 {
+        t.Errorf("Length() = %d, expected 4", n)
+    }
+}
+
+func TestMapShared(t *testing.T) {
+    lower := func(s string) string { return strings.ToLower(s) }
+    original := List("A", "b", "C", "d", "e")
+    expectList(t, "MapShared()", original.MapShared(lower), "a", "b", "c", "d", "e")
+
+    // Once a traversal has confirmed the unchanged suffix, it continues with the original nodes
+    mapped := original.MapShared(lower)
+    ToSlice(mapped)
+    want := original.Drop(3).Force().Tail
+    got := mapped.Drop(3).Force().Tail
+    if got != want {
+        t.Errorf("MapShared() didn't share the unchanged suffix")
+    }
+    expectList(t, "MapShared() traversed again", mapped, "a", "b", "c", "d", "e")
+
+    double := func(x int) int { return x * 2 }
+    expectList(t, "MapShared() of a changed infinite list", Iterate(func(x int) int { return x + 1 }, 1).MapShared(double).Take(3), 2, 4, 6)
+    expectList(t, "MapShared() of an unchanged infinite list", Repeat(0).MapShared(double).Take(3), 0, 0, 0)
+    expectList(t, "MapShared() of Empty", Empty.MapShared(double))
+}
+
+func TestMapSharedIsLazy(t *testing.T) {
+    calls := 0
+    identity := func(x int) int {
+        calls++
+        return x
+    }
+    Range(0, 1<<30, 1).MapShared(identity).Take(1).Force()
+    if calls != 1 {
+        t.Errorf("MapShared() called f %d times to force the head, expected 1", calls)
+    }
+
+    // The channel stays open, so forcing anything past what's been sent would block
+    ch := make(chan Anything, 3)
+    ch <- 1
+    ch <- 2
+    ch <- 3
+    head := make(chan Anything)
+    go func() {
+        v, _ := FromChannel(ch).MapShared(func(x Anything) Anything { return x }).Head()
+        head <- v
+    }()
+    select {
+    case v := <-head:
+        if v != 1 {
+            t.Errorf("MapShared() of a channel has head %v, expected 1", v)
+        }
+    case <-time.After(time.Second):
+        t.Errorf("MapShared() blocked forcing the head of an open channel")
+    }
+}
+
+// identityList is a materialized list of n ints, for benchmarking maps which change nothing
+func identityList(n int) (*LinkedList, func(x int) int) {
+    elements := make([]int, n)
+    for i := range elements {
+        elements[i] = i
+    }
+    return ToList(elements), func(x int) int { return x }
+}
+
+func BenchmarkMapIdentity(b *testing.B) {
+    list, identity := identityList(1000)
+    b.ReportAllocs()
+    for i := 0; i < b.N; i++ {
+        for node := list.Map(identity).Force(); node != nil; node = node.Tail.Force() {
+        }
+    }
+}
+
+func BenchmarkMapSharedIdentity(b *testing.B) {
+    list, identity := identityList(1000)
+    b.ReportAllocs()
+    for i := 0; i < b.N; i++ {
+        for node := list.MapShared(identity).Force(); node != nil; node = node.Tail.Force() {
+        }
+    }
+}
+
+func BenchmarkMapIdentityRetraversed(b *testing.B) {
+    list, identity := identityList(1000)
+    mapped := list.Map(identity)
+    b.ReportAllocs()
+    for i := 0; i < b.N; i++ {
+        for node := mapped.Force(); node != nil; node = node.Tail.Force() {
+        }
+    }
+}
+
+func BenchmarkMapSharedIdentityRetraversed(b *testing.B) {
+    list, identity := identityList(1000)
+    mapped := list.MapShared(identity)
+    b.ReportAllocs()
+    for i := 0; i < b.N; i++ {
+        for node := mapped.Force(); node != nil; node = node.Tail.Force() {
+        }
+    }
+}
+
+func BenchmarkApply(b *testing.B) {
+    add := func(x, y int) int { return x + y }
+    increment := Apply(add, 1)
+    b.ReportAllocs()
+    for i := 0; i < b.N; i++ {
+        increment(i)
+    }
+}
+
+func TestDistinctCount(t *testing.T) {
+    if n := List(1, 2, 2, 3, 1).DistinctCount(); n != 3 {
+        t.Errorf("DistinctCount() = %d, expected 3", n)
+    }
+    // Slices aren't hashable, so these go through the DeepEqual fallback
+    mixed := List(1, []int{1, 2}, "a", []int{1, 2}, 1, []int{2})
+    if n := mixed.DistinctCount(); n != 4 {
+        t.Errorf("DistinctCount() with unhashable elements = %d, expected 4", n)
+    }
+    if n := Empty.DistinctCount(); n != 0 {
+        t.Errorf("DistinctCount() of Empty = %d, expected 0", n)
+    }
+}
+
+func TestQuantileApproxSmallLists(t *testing.T) {
+    for _, c := range []struct {
+        list     *LinkedList
+        q        float64
+        expected float64
+    }{
+        {List(1, 2, 3, 4, 5), 0.99, 4.96},
+        {List(1, 2, 3, 4, 5), 0, 1},
+        {List(5, 4, 3, 2, 1), 0.5, 3},
+        {List(1, 2, 3), 1, 3},
+    } {
+        got, ok := c.list.QuantileApprox(c.q)
+        if !ok || math.Abs(got-c.expected) > 1e-9 {
+            t.Errorf("%v.QuantileApprox(%v) = %v, %v, expected %v, true", c.list, c.q, got, ok, c.expected)
+        }
+    }
+    if _, ok := Empty.QuantileApprox(0.5); ok {
+        t.Errorf("QuantileApprox() of Empty reported a result")
+    }
+}
+
+func TestMapValue(t *testing.T) {
+    calls := 0
+    double := func(x int) int {
+        calls++
+        return x * 2
+    }
+    parseDouble := ApplyMulti(strconv.Atoi).MapValue(double)
+
+    if val, err := parseDouble("21"); val != 42 || err != nil {
+        t.Errorf("MapValue() = %v, %v, expected 42, nil", val, err)
+    }
+    val, err := parseDouble("nope")
+    if val != 0 || err == nil {
+        t.Errorf("MapValue() = %v, %v, expected 0 and an error", val, err)
+    }
+    if calls != 1 {
+        t.Errorf("MapValue() called f %d times, expected it to be skipped on error", calls)
+    }
+}
+
+func TestMapLongList(t *testing.T) {
+    const n = 1000000
+    increment := func(x int) int { return x + 1 }
+    elements := ToSlice(Range(0, n, 1).Map(increment))
+    if len(elements) != n || elements[n-1] != n {
+        t.Errorf("Map() over %d elements gave %d elements, ending in %v", n, len(elements), elements[len(elements)-1])
+    }
+}
+
+func TestCount(t *testing.T) {
+    list := List(1, 2, 3, 4)
+    if n := list.Count(func(x int) bool { return x%2 == 0 }); n != 2 {
+        t.Errorf("Count() of evens = %d, expected 2", n)
+    }
+    if n := list.Count(nil); n != 4 {
+        t.Errorf("Count(nil) = %d, expected 4", n)
+    }
+}
+
+func TestToList(t *testing.T) {
+    expectList(t, "ToList() of a slice", ToList([]int{1, 2, 3}), 1, 2, 3)
+    expectList(t, "ToList() of an array", ToList([3]int{1, 2, 3}), 1, 2, 3)
+    expectList(t, "ToList() of an empty slice", ToList([]int{}))
+}
+
+func TestMemoize(t *testing.T) {
+    var fib Function
+    fib = Memoize(func(n int) int {
+        if n < 2 {
+            return n
+        }
+        return fib(n-1).(int) + fib(n-2).(int)
+    })
+    if got := fib(80); got != 23416728348467685 {
+        t.Errorf("fib(80) = %v, expected 23416728348467685", got)
+    }
+
+    type wrapper struct{ Value Anything }
+    calls := 0
+    length := Memoize(func(w wrapper) int {
+        calls++
+        return len(w.Value.([]int))
+    })
+    // A comparable struct type holding an unhashable value must fall back to a string key
+    length(wrapper{[]int{1, 2}})
+    if got := length(wrapper{[]int{1, 2}}); got != 2 || calls != 1 {
+        t.Errorf("Memoize() = %v after %d calls, expected 2 after 1 call", got, calls)
+    }
+
+    isNil := Memoize(func(p *int) bool { return p == nil })
+    if got := isNil(nil); got != true {
+        t.Errorf("Memoize() with a nil argument = %v, expected true", got)
+    }
+}
+
+func TestLengthUpTo(t *testing.T) {
+    if n := Repeat(1).LengthUpTo(5); n != 5 {
+        t.Errorf("Repeat(1).LengthUpTo(5) = %d, expected 5", n)
+    }
+    if n := List(1, 2).LengthUpTo(5); n != 2 {
+        t.Errorf("LengthUpTo(5) of a shorter list = %d, expected 2", n)
+    }
+}
+
+func TestDropForcedTwice(t *testing.T) {
+    dropped := List(1, 2, 3, 4, 5).Drop(2)
+    expectList(t, "Drop(2)", dropped, 3, 4, 5)
+    expectList(t, "Drop(2) forced again", dropped, 3, 4, 5)
+}
+
+func TestMemoizeRetriesAfterPanic(t *testing.T) {
+    failed := false
+    flaky := func(x int) int {
+        if x == 2 && !failed {
+            failed = true
+            panic("flaky")
+        }
+        return x
+    }
+    memoized := List(1, 2, 3).Map(flaky).Memoize()
+
+    func() {
+        defer func() {
+            if r := recover(); r != "flaky" {
+                t.Errorf("first traversal panicked with %v, expected flaky", r)
+            }
+        }()
+        ToSlice(memoized)
+    }()
+    expectList(t, "Memoize() after a recovered panic", memoized, 1, 2, 3)
+}